@@ -13,10 +13,15 @@ type Admin struct{}
 
 // DropDB does indeed nuke all DB data, returning it to a virgin state
 func (Admin) DropDB(dg *dgo.Dgraph) error {
-	return dg.Alter(context.Background(), &api.Operation{DropAll: true})
+	err := dg.Alter(context.Background(), &api.Operation{DropAll: true})
+	// reset after alter, so schema cached by concurrent queries during it is dropped too
+	resetSchemaCache()
+	return err
 }
 
 // MigrateSchema runs schema migration
 func (Admin) MigrateSchema(dg *dgo.Dgraph, schema string) error {
-	return dg.Alter(context.Background(), &api.Operation{Schema: schema})
+	err := dg.Alter(context.Background(), &api.Operation{Schema: schema})
+	resetSchemaCache()
+	return err
 }
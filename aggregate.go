@@ -0,0 +1,108 @@
+package ndgom
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// --------------------------------------- Simple ---------------------------------------
+
+// Min returns minimum value of numeric predicate of nodes matching example type, its populated fields and Where conditions.
+// Returns 0 when no nodes match.
+func (Simple) Min(txn Txn, example interface{}, predicate string, opts ...Option) (value float64, err error) {
+	return aggregate(txn, "min", example, predicate, newOptions(opts))
+}
+
+// Max returns maximum value of numeric predicate of nodes matching example type, its populated fields and Where conditions.
+// Returns 0 when no nodes match.
+func (Simple) Max(txn Txn, example interface{}, predicate string, opts ...Option) (value float64, err error) {
+	return aggregate(txn, "max", example, predicate, newOptions(opts))
+}
+
+// Sum returns sum of numeric predicate of nodes matching example type, its populated fields and Where conditions.
+func (Simple) Sum(txn Txn, example interface{}, predicate string, opts ...Option) (value float64, err error) {
+	return aggregate(txn, "sum", example, predicate, newOptions(opts))
+}
+
+// Avg returns average of numeric predicate of nodes matching example type, its populated fields and Where conditions.
+// Returns 0 when no nodes match.
+func (Simple) Avg(txn Txn, example interface{}, predicate string, opts ...Option) (value float64, err error) {
+	return aggregate(txn, "avg", example, predicate, newOptions(opts))
+}
+
+// aggregate makes db query aggregating predicate with value variable
+func aggregate(txn Txn, function string, example interface{}, predicate string, o *options) (value float64, err error) {
+	if err = validateInput(example); err != nil {
+		return 0, err
+	}
+	conds, err := matchConds(txn, example, o)
+	if err != nil {
+		return 0, err
+	}
+	q := fmt.Sprintf(`
+	{
+	  var(func: %s) %s {
+	    v as %s
+	  }
+	  q() {
+	    result: %s(val(v))
+	  }
+	}`, conds[0], filterOf(conds[1:]), predicate, function)
+	resp, err := txn.Query(q)
+	if err != nil {
+		return 0, err
+	}
+	var res struct {
+		Q []struct {
+			Result float64 `json:"result"`
+		} `json:"q"`
+	}
+	if err = json.Unmarshal(resp.GetJson(), &res); err != nil {
+		return 0, err
+	}
+	if len(res.Q) == 0 {
+		return 0, nil
+	}
+	return res.Q[0].Result, nil
+}
+
+// --------------------------------------- Easy ---------------------------------------
+
+// Min returns minimum value of numeric predicate of nodes matching example type, its populated fields and Where conditions.
+// Returns 0 when no nodes match.
+func (Easy) Min(example interface{}, predicate string, opts ...Option) (value float64, err error) {
+	err = withReadTxn(func(txn Txn) error {
+		value, err = Simple{}.Min(txn, example, predicate, opts...)
+		return err
+	})
+	return value, err
+}
+
+// Max returns maximum value of numeric predicate of nodes matching example type, its populated fields and Where conditions.
+// Returns 0 when no nodes match.
+func (Easy) Max(example interface{}, predicate string, opts ...Option) (value float64, err error) {
+	err = withReadTxn(func(txn Txn) error {
+		value, err = Simple{}.Max(txn, example, predicate, opts...)
+		return err
+	})
+	return value, err
+}
+
+// Sum returns sum of numeric predicate of nodes matching example type, its populated fields and Where conditions.
+func (Easy) Sum(example interface{}, predicate string, opts ...Option) (value float64, err error) {
+	err = withReadTxn(func(txn Txn) error {
+		value, err = Simple{}.Sum(txn, example, predicate, opts...)
+		return err
+	})
+	return value, err
+}
+
+// Avg returns average of numeric predicate of nodes matching example type, its populated fields and Where conditions.
+// Returns 0 when no nodes match.
+func (Easy) Avg(example interface{}, predicate string, opts ...Option) (value float64, err error) {
+	err = withReadTxn(func(txn Txn) error {
+		value, err = Simple{}.Avg(txn, example, predicate, opts...)
+		return err
+	})
+	return value, err
+}
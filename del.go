@@ -0,0 +1,184 @@
+package ndgom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dgraph-io/dgo/protos/api"
+)
+
+// Del deletes node of specified uid, if it has type dgTypes, together with owned nodes of uids.
+// Owned nodes are deleted only if the node exists and has requested type.
+func (Stateless) Del(txn Txn, uid, dgTypes string, owned ...string) (err error) {
+	q := fmt.Sprintf(`
+	query {
+	  U as q(func: uid(%s)) @filter(eq(dgraph.type, %s)) {
+	    uid
+	  }
+	}`, uid, dgTypes)
+	var nquads bytes.Buffer
+	nquads.WriteString("uid(U) * * .\n")
+	for _, o := range owned {
+		fmt.Fprintf(&nquads, "<%s> * * .\n", o)
+	}
+	// only delete if uid of specified type found
+	resp, err := txn.Do(&api.Request{
+		Query: q,
+		Mutations: []*api.Mutation{{
+			Cond:      "@if(eq(len(U), 1))",
+			DelNquads: nquads.Bytes(),
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	var res struct {
+		Q []json.RawMessage `json:"q"`
+	}
+	if err = json.Unmarshal(resp.GetJson(), &res); err != nil {
+		return err
+	}
+	if len(res.Q) == 0 {
+		return fmt.Errorf("ndgom.Stateless{}.Del: %w", ErrNotExist)
+	}
+	return nil
+}
+
+// Del deletes node based on uid and type. Recursively deletes all nodes reachable through edges tagged with owned:"true".
+// Everything happens in the supplied transaction, and each owned node is checked to have the type of its field.
+func (Simple) Del(txn Txn, obj interface{}) (err error) {
+	if err = validateInput(obj); err != nil {
+		return err
+	}
+	uid := getUID(obj)
+	owned, err := ownedUIDs(txn, reflect.TypeOf(obj).Elem(), uid)
+	if err != nil {
+		return err
+	}
+	return Stateless{}.Del(txn, uid, getDgType(obj), owned...)
+}
+
+// Del deletes node based on uid and type. Recursively deletes all nodes reachable through edges tagged with owned:"true".
+// Everything happens in a single transaction, and each owned node is checked to have the type of its field.
+func (Easy) Del(obj interface{}) (err error) {
+	return withTxn(func(txn Txn) error {
+		return Simple{}.Del(txn, obj)
+	})
+}
+
+// ownedEdge is an edge tagged with owned:"true"
+type ownedEdge struct {
+	predicate string
+	t         reflect.Type
+}
+
+// ownedEdges returns edges of struct t tagged with owned:"true"
+func ownedEdges(t reflect.Type) (edges []ownedEdge) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("owned") != "true" {
+			continue
+		}
+		name, ok := jsonName(field)
+		if !ok || isReverse(name) {
+			continue
+		}
+		et, ok := edgeType(field.Type)
+		if !ok {
+			panic(fmt.Sprintf("owned tag is only supported on edges, but field %s.%s isn't one", t.Name(), field.Name))
+		}
+		edges = append(edges, ownedEdge{predicate: name, t: et})
+	}
+	return edges
+}
+
+// ownedUIDs returns uids of all nodes reachable from node of uid and type t through owned edges, level by level
+func ownedUIDs(txn Txn, t reflect.Type, uid string) (owned []string, err error) {
+	visited := map[string]bool{uid: true}
+	level := map[reflect.Type][]string{t: {uid}}
+	for len(level) > 0 {
+		next := make(map[reflect.Type][]string)
+		for t, uids := range level {
+			edges := ownedEdges(t)
+			if len(edges) == 0 {
+				continue
+			}
+			children, err := ownedChildren(txn, uids, edges)
+			if err != nil {
+				return nil, err
+			}
+			for i, edge := range edges {
+				for _, child := range children[i] {
+					if visited[child] {
+						continue
+					}
+					visited[child] = true
+					owned = append(owned, child)
+					next[edge.t] = append(next[edge.t], child)
+				}
+			}
+		}
+		level = next
+	}
+	return owned, nil
+}
+
+// ownedChildren queries uids of nodes on each of the edges, which have the type of the edge
+func ownedChildren(txn Txn, uids []string, edges []ownedEdge) (children [][]string, err error) {
+	var sb strings.Builder
+	for _, edge := range edges {
+		fmt.Fprintf(&sb, "\n\t    %s %s { uid }", edge.predicate, typeFilter(parseTagDgType(edge.t)))
+	}
+	q := fmt.Sprintf(`
+	{
+	  q(func: uid(%s)) {%s
+	  }
+	}`, strings.Join(uids, ", "), sb.String())
+	resp, err := txn.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Q []map[string]json.RawMessage `json:"q"`
+	}
+	if err = json.Unmarshal(resp.GetJson(), &res); err != nil {
+		return nil, err
+	}
+	children = make([][]string, len(edges))
+	for _, node := range res.Q {
+		for i, edge := range edges {
+			raw, ok := node[edge.predicate]
+			if !ok {
+				continue
+			}
+			uids, err := decodeUIDs(raw)
+			if err != nil {
+				return nil, err
+			}
+			children[i] = append(children[i], uids...)
+		}
+	}
+	return children, nil
+}
+
+// decodeUIDs decodes uids of edge, which can be a single node or list of nodes
+func decodeUIDs(raw json.RawMessage) (uids []string, err error) {
+	type node struct {
+		UID string `json:"uid"`
+	}
+	var nodes []node
+	if err = json.Unmarshal(raw, &nodes); err != nil {
+		var n node
+		if err = json.Unmarshal(raw, &n); err != nil {
+			return nil, err
+		}
+		nodes = []node{n}
+	}
+	for _, n := range nodes {
+		uids = append(uids, n.UID)
+	}
+	return uids, nil
+}
@@ -2,7 +2,6 @@ package ndgom
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
@@ -16,6 +15,21 @@ import (
 var (
 	dg         *dgo.Dgraph
 	txnTimeout = 60 * time.Second
+	txRetries  = 3
+	txBackoff  = 10 * time.Millisecond
+	readMode   = ReadOnly
+)
+
+// ReadMode specifies what kind of transactions are used for Easy{} reads
+type ReadMode int
+
+const (
+	// ReadOnly uses read only transactions. Default.
+	ReadOnly ReadMode = iota
+	// BestEffort uses read only best effort transactions, which have lower latency, but may return slightly stale data
+	BestEffort
+	// ReadWrite uses read-write transactions, same as mutations
+	ReadWrite
 )
 
 // Easy groups Easy{}.API methods, the easiest one to use.
@@ -26,11 +40,24 @@ type Easy struct{}
 // If timeout is 0, default value of 60 seconds will be used.
 func (Easy) Init(d *dgo.Dgraph, timeout time.Duration) {
 	dg = d
+	resetSchemaCache()
 	if timeout > 0 {
 		txnTimeout = timeout
 	}
 }
 
+// SetReadMode sets what kind of transactions are used for reads, i.e. in GetByID, Get and ReadTx.
+// Default is ReadOnly.
+func (Easy) SetReadMode(mode ReadMode) {
+	readMode = mode
+}
+
+// ResetSchemaCache drops cached schema, which Get uses to pick indexed predicates.
+// Call it after altering schema outside of ndgom, i.e. with dg.Alter.
+func (Easy) ResetSchemaCache() {
+	resetSchemaCache()
+}
+
 // Debug enables logging of debug information, like ignored fields during parsing etc.
 // Uses the default std logger
 func Debug() {
@@ -38,75 +65,88 @@ func Debug() {
 }
 
 // GetByID makes db query by uid and unmarshals result as object
-func (Easy) GetByID(result interface{}) (err error) {
+func (Easy) GetByID(result interface{}, opts ...Option) (err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), txnTimeout)
 	defer cancel()
-	txn := ndgo.NewTxn(ctx, dg.NewTxn())
+	txn := newReadTxn(ctx)
 	defer txn.Discard()
 
 	uid := getUID(result)
-	return Simple{}.GetByID(txn, uid, result)
+	return Simple{}.GetByID(txn, uid, result, opts...)
 }
 
 // Get makes db query and populates result with found value or values.
 // If result is struct, returns first result. If is slice, returns all found results.
-func (Easy) Get(result interface{}) (err error) {
+func (Easy) Get(result interface{}, opts ...Option) (err error) {
 	// pre
 	if err = validateInput(result); err != nil {
 		return err
 	}
 	kind := getKind(result)
+	o := newOptions(opts)
 	ctx, cancel := context.WithTimeout(context.Background(), txnTimeout)
 	defer cancel()
-	txn := ndgo.NewTxn(ctx, dg.NewTxn())
+	txn := newReadTxn(ctx)
 	defer txn.Discard()
 
 	// get values from fields, and construct query based on them
-	f := getPopulatedFields(result, kind)
-	// check if any fields are populated
-	if len(f) == 0 {
-		return fmt.Errorf("need to specify at least one struct field for Get")
+	f := getPopulatedFields(result, kind, o)
+	// check if any fields are populated, or conditions set
+	if len(f) == 0 && len(o.conds) == 0 {
+		return fmt.Errorf("need to specify at least one struct field or Where condition for Get")
 	}
 
 	// check if uid query possible
 	uid, ok := f["uid"]
 	if ok {
 		// this is basically identical to GetByID, but with filter and checking result Kind
-		filter := ""
-		if len(f) > 1 {
-			filters := make([]string, 0, len(f)-1)
-			for k, v := range f {
-				if k == "uid" {
-					continue
-				}
-				filters = append(filters, fmt.Sprintf(`eq(%s, %s)`, k, v))
+		conds := make([]Cond, 0, len(f)-1+len(o.conds))
+		for k, v := range f {
+			if k == "uid" {
+				continue
 			}
-			filter = "@filter(" + strings.Join(filters, " AND ") + ")" // final format is "@filter(eq(fieldName, fieldVal) AND eq(f2,v2) ...)"
+			conds = append(conds, Cond{function: "eq", predicate: k, args: v})
 		}
+		conds = append(conds, o.conds...)
+		filter := filterOf(conds) // final format is "@filter(eq(fieldName, fieldVal) AND eq(f2,v2) ...)"
 		dgType := getDgType(result)
-		resp, err := ndgo.Query{}.GetUIDExpandType("q", "uid", uid, ",first: 1", filter, "uid dgraph.type", dgType).Run(txn)
+		q := ndgo.Query{}.GetUIDExpandType("q", "uid", uid, ",first: 1"+orderParams(result, o), filter, getPredicates(result, o), dgType)
+		resp, err := txn.Query(string(q))
 		if err != nil {
 			return err
 		}
+		dec := newDecoder(result, o)
 		switch kind {
 		case reflect.Struct:
-			return json.Unmarshal(ndgo.Unsafe{}.FlattenRespToObject(resp.GetJson()), &result)
+			return dec(ndgo.Unsafe{}.FlattenRespToObject(resp.GetJson()), &result)
 		case reflect.Slice:
-			return json.Unmarshal(ndgo.Unsafe{}.FlattenRespToArray(resp.GetJson()), &result)
+			return dec(ndgo.Unsafe{}.FlattenRespToArray(resp.GetJson()), &result)
 		}
 	}
 
-	// query
-	if len(f) != 1 {
-		return fmt.Errorf("One field must be populated for Get to work. Get on multiple fields not implemented yet")
-	}
-	for k, v := range f {
-		switch kind {
-		case reflect.Struct:
-			return Simple{}.GetOne(txn, k, v, result)
-		case reflect.Slice:
-			return Simple{}.Get(txn, k, v, result)
+	// query, with indexed populated field as root function and others as filter, or first condition if none are populated
+	var root Cond
+	if len(f) == 0 {
+		root, o.conds = o.conds[0], o.conds[1:]
+	} else {
+		predicate, err := rootPredicate(txn, f)
+		if err != nil {
+			return err
+		}
+		root = Cond{function: "eq", predicate: predicate, args: f[predicate]}
+		conds := make([]Cond, 0, len(f)-1+len(o.conds))
+		for _, k := range sortedKeys(f) {
+			if k != predicate {
+				conds = append(conds, Cond{function: "eq", predicate: k, args: f[k]})
+			}
 		}
+		o.conds = append(conds, o.conds...)
+	}
+	switch kind {
+	case reflect.Struct:
+		return findOne(txn, root, result, o)
+	case reflect.Slice:
+		return find(txn, root, result, o)
 	}
 	panic("internal error: should not have gotten here")
 }
@@ -116,7 +156,7 @@ func (Easy) Get(result interface{}) (err error) {
 func (Easy) New(obj interface{}) (err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), txnTimeout)
 	defer cancel()
-	txn := ndgo.NewTxn(ctx, dg.NewTxn())
+	txn := newTxn(ctx)
 	defer txn.Discard()
 
 	err = Simple{}.New(txn, obj)
@@ -126,14 +166,30 @@ func (Easy) New(obj interface{}) (err error) {
 	return txn.Commit()
 }
 
-// Upd updates node based on uid and changed fields, and unmarshals updated result into supplied obj
-func (Easy) Upd(obj interface{}) (err error) {
+// Upd updates node based on uid and changed fields, and unmarshals updated result into supplied obj.
+// Empty fields are not updated, unless set with Clear option, which deletes them. Options like Langs apply to the updated result.
+func (Easy) Upd(obj interface{}, opts ...Option) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), txnTimeout)
+	defer cancel()
+	txn := newTxn(ctx)
+	defer txn.Discard()
+
+	err = Simple{}.Upd(txn, obj, opts...)
+	if err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// UpdFields updates only listed fields of node, set by Go field names or predicates, and unmarshals updated result into supplied obj.
+// Other fields are left untouched, even if they are not empty. Listed fields, which are empty, are deleted.
+func (Easy) UpdFields(obj interface{}, fields ...string) (err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), txnTimeout)
 	defer cancel()
-	txn := ndgo.NewTxn(ctx, dg.NewTxn())
+	txn := newTxn(ctx)
 	defer txn.Discard()
 
-	err = Simple{}.Upd(txn, obj)
+	err = Simple{}.UpdFields(txn, obj, fields...)
 	if err != nil {
 		return err
 	}
@@ -142,6 +198,47 @@ func (Easy) Upd(obj interface{}) (err error) {
 
 // --------------------------------------- helpers ---------------------------------------
 
+// withReadTxn runs fn in new read transaction
+func withReadTxn(fn func(txn Txn) error) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), txnTimeout)
+	defer cancel()
+	txn := newReadTxn(ctx)
+	defer txn.Discard()
+
+	return fn(txn)
+}
+
+// withTxn runs fn in new transaction, and commits it if fn succeeds
+func withTxn(fn func(txn Txn) error) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), txnTimeout)
+	defer cancel()
+	txn := newTxn(ctx)
+	defer txn.Discard()
+
+	err = fn(txn)
+	if err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// newTxn creates new transaction, observed by Observer set for Easy{} client
+func newTxn(ctx context.Context) Txn {
+	return Observe(NewNdgoTxn(ndgo.NewTxn(ctx, dg.NewTxn())), observerOf(dg))
+}
+
+// newReadTxn creates new transaction for reads, according to set ReadMode, observed by Observer set for Easy{} client
+func newReadTxn(ctx context.Context) Txn {
+	switch readMode {
+	case ReadOnly:
+		return Observe(NewNdgoTxn(ndgo.NewTxn(ctx, dg.NewReadOnlyTxn())), observerOf(dg))
+	case BestEffort:
+		return Observe(NewNdgoTxn(ndgo.NewTxn(ctx, dg.NewReadOnlyTxn().BestEffort())), observerOf(dg))
+	default:
+		return newTxn(ctx)
+	}
+}
+
 func getUID(obj interface{}) (uid string) {
 	t := reflect.TypeOf(obj).Elem()
 	// validate if fields exist how we need them
@@ -171,16 +268,16 @@ func getKind(obj interface{}) reflect.Kind {
 	}
 }
 
-func getPopulatedFields(obj interface{}, kind reflect.Kind) (fields map[string]string) {
+func getPopulatedFields(obj interface{}, kind reflect.Kind, o *options) (fields map[string]string) {
 	fieldValues := make(map[string][]string)
 
 	switch kind {
 	case reflect.Struct:
-		insertPopulatedFieldsOfSingleStructIntoMap(obj, &fieldValues)
+		insertPopulatedFieldsOfSingleStructIntoMap(obj, &fieldValues, o)
 	case reflect.Slice:
 		s := reflect.ValueOf(obj).Elem()
 		for i := 0; i < s.Len(); i++ {
-			insertPopulatedFieldsOfSingleStructIntoMap(s.Index(i), &fieldValues)
+			insertPopulatedFieldsOfSingleStructIntoMap(s.Index(i), &fieldValues, o)
 		}
 	}
 
@@ -196,7 +293,7 @@ func getPopulatedFields(obj interface{}, kind reflect.Kind) (fields map[string]s
 	return fields
 }
 
-func insertPopulatedFieldsOfSingleStructIntoMap(obj interface{}, fieldValues *map[string][]string) {
+func insertPopulatedFieldsOfSingleStructIntoMap(obj interface{}, fieldValues *map[string][]string, o *options) {
 	// special case
 	// when iterating over slice elements, we already have the elements as reflect.Value
 	v, ok := obj.(reflect.Value)
@@ -215,6 +312,14 @@ func insertPopulatedFieldsOfSingleStructIntoMap(obj interface{}, fieldValues *ma
 			continue
 		}
 		predicateName := strings.Split(tag, ",")[0]
+		if _, _, ok := splitFacet(predicateName); ok {
+			log.Debugf("ndgom.Get.getPopulatedFields: skipping facet field - not implemented")
+			continue
+		}
+		// filter on language variant, which can be used in functions
+		if p, lang, ok := splitLang(predicateName); ok {
+			predicateName = langFilterPredicate(p, lang, o)
+		}
 		// log.Debugf("Field: %s\tType: %v\tKind: %v\tValue: %v\tJsonFieldName:%v\n", vtf.Name, ft, ft.Kind(), f.Interface(), predicateName)
 
 		s := ""
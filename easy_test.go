@@ -1,6 +1,9 @@
 package ndgom_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/dgraph-io/dgo"
@@ -120,3 +123,1108 @@ func TestEaGet(t *testing.T) {
 
 // func TestEaGetErr(t *testing.T) {
 //}
+
+func TestEaTx(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+
+	// add and update element in one managed transaction
+	var s1 testStruct
+	err = ea.Tx(context.Background(), func(tx *ndgom.Tx) error {
+		s1 = testStruct{
+			Name: firstName,
+			Attr: firstAttr,
+		}
+		if err := tx.New(&s1); err != nil {
+			return err
+		}
+		upd1 := testStruct{
+			UID:  s1.UID,
+			Name: "updatedName",
+		}
+		return tx.Upd(&upd1)
+	})
+	require.NoError(t, err)
+
+	// check if added correctly
+	expected := testStruct{
+		UID:  s1.UID,
+		Type: []string{testType},
+		Name: "updatedName",
+		Attr: firstAttr,
+	}
+	eaValidateIfElementMatchesDatabase(t, dg, &expected)
+}
+
+func TestEaTxRetry(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add element with default values
+	s1 := eaAddNewElement(t, dg)
+
+	// conflicting write is committed during first attempt, so tx is aborted and retried
+	attempts := 0
+	err = ea.Tx(context.Background(), func(tx *ndgom.Tx) error {
+		attempts++
+		upd := testStruct{UID: s1.UID, Name: "txName"}
+		if err := tx.Upd(&upd); err != nil {
+			return err
+		}
+		if attempts == 1 {
+			return ea.Upd(&testStruct{UID: s1.UID, Name: "conflictName"})
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts)
+	expected := testStruct{
+		UID:  s1.UID,
+		Type: []string{testType},
+		Name: "txName",
+		Attr: firstAttr,
+	}
+	eaValidateIfElementMatchesDatabase(t, dg, &expected)
+
+	// conflicting write during every attempt exhausts retries
+	ea.SetTxRetries(1, 0)
+	defer ea.SetTxRetries(3, 0)
+	attempts = 0
+	err = ea.Tx(context.Background(), func(tx *ndgom.Tx) error {
+		attempts++
+		upd := testStruct{UID: s1.UID, Name: "txName2"}
+		if err := tx.Upd(&upd); err != nil {
+			return err
+		}
+		return ea.Upd(&testStruct{UID: s1.UID, Name: "conflictName"})
+	})
+	require.ErrorIs(t, err, dgo.ErrAborted)
+	require.Equal(t, 2, attempts)
+	expected.Name = "conflictName"
+	eaValidateIfElementMatchesDatabase(t, dg, &expected)
+}
+
+func TestEaTxErr(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+
+	// returned error discards the transaction
+	errTest := errors.New("test error")
+	var s1 testStruct
+	err = ea.Tx(context.Background(), func(tx *ndgom.Tx) error {
+		s1 = testStruct{
+			Name: firstName,
+			Attr: firstAttr,
+		}
+		if err := tx.New(&s1); err != nil {
+			return err
+		}
+		return errTest
+	})
+	require.ErrorIs(t, err, errTest)
+
+	// so nothing was added
+	get1 := []testStruct{{Name: firstName}}
+	err = ea.Get(&get1)
+	require.NoError(t, err)
+	require.Empty(t, get1)
+}
+
+func TestEaReadTx(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add element with default values
+	s1 := eaAddNewElement(t, dg)
+
+	for _, mode := range []ndgom.ReadMode{ndgom.ReadOnly, ndgom.BestEffort} {
+		ea.SetReadMode(mode)
+		var get1 testStruct
+		err = ea.ReadTx(context.Background(), func(tx *ndgom.Tx) error {
+			return tx.GetByID(s1.UID, &get1)
+		})
+		require.NoError(t, err)
+		require.Exactly(t, s1, get1)
+	}
+	ea.SetReadMode(ndgom.ReadOnly)
+}
+
+func TestEaFacets(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add element with scalar facet and edge with facet
+	s1 := testFacetStruct{
+		Name:       firstName,
+		NameOrigin: firstAttr,
+		Edge: []*testFacetStruct{{
+			Type:       []string{testType},
+			Name:       secondName,
+			EdgeWeight: 0.5,
+		}},
+	}
+	err = ea.New(&s1)
+	require.NoError(t, err)
+
+	// check if facets are returned
+	get1 := testFacetStruct{UID: s1.UID}
+	err = ea.GetByID(&get1)
+	require.NoError(t, err)
+	require.Equal(t, firstAttr, get1.NameOrigin)
+	require.Len(t, get1.Edge, 1)
+	require.Equal(t, secondName, get1.Edge[0].Name)
+	require.Equal(t, 0.5, get1.Edge[0].EdgeWeight)
+}
+
+func TestEaReverse(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add parent with edge to child
+	s1 := testReverseStruct{
+		Name: firstName,
+		Edge: []testReverseStruct{{
+			Type: []string{testType},
+			Name: secondName,
+		}},
+		Parents: []testReverseStruct{{UID: "0x1"}}, // should be ignored
+	}
+	err = ea.New(&s1)
+	require.NoError(t, err)
+
+	// get child by name and check its parent
+	get1 := testReverseStruct{Name: secondName}
+	err = ea.Get(&get1)
+	require.NoError(t, err)
+	require.Len(t, get1.Parents, 1)
+	require.Equal(t, s1.UID, get1.Parents[0].UID)
+	require.Equal(t, firstName, get1.Parents[0].Name)
+}
+
+func TestEaLang(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add element with names in multiple languages
+	s1 := testLangStruct{
+		Name:   firstName,
+		NameEn: secondName,
+		NameFr: thirdName,
+	}
+	err = ea.New(&s1)
+	require.NoError(t, err)
+
+	// get by language tagged value, with language preference
+	get1 := testLangStruct{NameFr: thirdName}
+	err = ea.Get(&get1, ndgom.Langs("de", "fr"))
+	require.NoError(t, err)
+	require.Equal(t, s1.UID, get1.UID)
+	require.Equal(t, secondName, get1.NameEn)
+	require.Equal(t, thirdName, get1.NamePref)
+	require.Equal(t, map[string]string{"": firstName, "en": secondName, "fr": thirdName}, get1.NameLangs)
+
+	// update, with language preference applied to updated result
+	upd1 := testLangStruct{UID: s1.UID, NameEn: "updatedName"}
+	err = ea.Upd(&upd1, ndgom.Langs("fr"))
+	require.NoError(t, err)
+	require.Equal(t, "updatedName", upd1.NameEn)
+	require.Equal(t, thirdName, upd1.NamePref)
+}
+
+func TestEaGeo(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add element with location
+	s1 := testGeoStruct{
+		Name:     firstName,
+		Location: &ndgom.GeoPoint{Lng: 21.0122, Lat: 52.2297},
+	}
+	err = ea.New(&s1)
+	require.NoError(t, err)
+
+	// near
+	var near []testGeoStruct
+	err = ea.Near(predicateLoc, ndgom.GeoPoint{Lng: 21.0, Lat: 52.23}, 2000, &near)
+	require.NoError(t, err)
+	require.Len(t, near, 1)
+	require.Equal(t, s1.UID, near[0].UID)
+	require.Equal(t, *s1.Location, *near[0].Location)
+
+	// within
+	polygon := ndgom.GeoPolygon{{
+		{Lng: 20.9, Lat: 52.1},
+		{Lng: 21.1, Lat: 52.1},
+		{Lng: 21.1, Lat: 52.3},
+		{Lng: 20.9, Lat: 52.3},
+		{Lng: 20.9, Lat: 52.1},
+	}}
+	var within []testGeoStruct
+	err = ea.Within(predicateLoc, polygon, &within)
+	require.NoError(t, err)
+	require.Len(t, within, 1)
+
+	// far away
+	var near2 []testGeoStruct
+	err = ea.Near(predicateLoc, ndgom.GeoPoint{Lng: 0, Lat: 0}, 2000, &near2)
+	require.NoError(t, err)
+	require.Empty(t, near2)
+}
+
+func TestEaSearch(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add element with multi word attribute
+	s1 := testStruct{
+		Name: firstName,
+		Attr: "quick brown foxes",
+	}
+	err = ea.New(&s1)
+	require.NoError(t, err)
+
+	cases := []struct {
+		mode  ndgom.SearchMode
+		text  string
+		found bool
+	}{
+		{ndgom.AnyOfTerms, "brown cow", true},
+		{ndgom.AllOfTerms, "brown cow", false},
+		{ndgom.AnyOfText, "fox", true},
+		{ndgom.AllOfText, "quick fox", true},
+		{ndgom.Regexp, "^quick.*s$", true},
+		{ndgom.Regexp, "/^QUICK/i", true},
+		{ndgom.Match, "quick brown fixes", true},
+	}
+	for _, c := range cases {
+		var res []testStruct
+		err = ea.Search(predicateAttr, c.mode, c.text, &res)
+		require.NoError(t, err)
+		if c.found {
+			require.Len(t, res, 1, "%s %s", c.mode, c.text)
+			require.Exactly(t, s1, res[0])
+		} else {
+			require.Empty(t, res, "%s %s", c.mode, c.text)
+		}
+	}
+
+	err = ea.Search(predicateAttr, "eq", firstAttr, &[]testStruct{})
+	require.ErrorIs(t, err, ndgom.ErrSearchMode)
+}
+
+func TestEaGetWhere(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add elements with counts
+	for i, name := range []string{firstName, secondName, thirdName, fourthName} {
+		s := testCountStruct{Name: name, Count: i + 1}
+		err = ea.New(&s)
+		require.NoError(t, err)
+	}
+
+	// conditions only
+	var get1 []testCountStruct
+	err = ea.Get(&get1, ndgom.Where(ndgom.Gt(predicateCount, 1), ndgom.Le(predicateCount, 3)))
+	require.NoError(t, err)
+	require.Len(t, get1, 2)
+
+	var get2 []testCountStruct
+	err = ea.Get(&get2, ndgom.Where(ndgom.Between(predicateCount, 1, 2)))
+	require.NoError(t, err)
+	require.Len(t, get2, 2)
+
+	// populated field with condition
+	get3 := []testCountStruct{{Name: firstName}, {Name: secondName}}
+	err = ea.Get(&get3, ndgom.Where(ndgom.Ge(predicateCount, 2)))
+	require.NoError(t, err)
+	require.Len(t, get3, 1)
+	require.Equal(t, secondName, get3[0].Name)
+
+	get4 := []testCountStruct{{Name: firstName}}
+	err = ea.Get(&get4, ndgom.Where(ndgom.Lt(predicateCount, 1)))
+	require.NoError(t, err)
+	require.Empty(t, get4)
+}
+
+func TestEaGetOrder(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add elements with counts, in mixed order
+	for _, c := range []int{3, 1, 4, 2} {
+		s := testCountStruct{Name: firstName, Count: c}
+		err = ea.New(&s)
+		require.NoError(t, err)
+	}
+	counts := func(res []testCountStruct) (c []int) {
+		for _, r := range res {
+			c = append(c, r.Count)
+		}
+		return c
+	}
+
+	// default order from tag
+	get1 := []testCountStruct{{Name: firstName}}
+	err = ea.Get(&get1)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3, 4}, counts(get1))
+
+	// overridden order
+	get2 := []testCountStruct{{Name: firstName}}
+	err = ea.Get(&get2, ndgom.OrderDesc(predicateCount))
+	require.NoError(t, err)
+	require.Equal(t, []int{4, 3, 2, 1}, counts(get2))
+
+	// first by order
+	get3 := testCountStruct{Name: firstName}
+	err = ea.Get(&get3, ndgom.OrderDesc(predicateCount))
+	require.NoError(t, err)
+	require.Equal(t, 4, get3.Count)
+}
+
+func TestEaAggregate(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add elements with counts
+	for i, c := range []int{3, 1, 4, 2} {
+		s := testCountStruct{Name: firstName, Count: c}
+		if i == 3 {
+			s.Name = secondName
+		}
+		err = ea.New(&s)
+		require.NoError(t, err)
+	}
+
+	// all nodes of type
+	v, err := ea.Min(&testCountStruct{}, predicateCount)
+	require.NoError(t, err)
+	require.Equal(t, 1.0, v)
+	v, err = ea.Max(&testCountStruct{}, predicateCount)
+	require.NoError(t, err)
+	require.Equal(t, 4.0, v)
+	v, err = ea.Sum(&testCountStruct{}, predicateCount)
+	require.NoError(t, err)
+	require.Equal(t, 10.0, v)
+	v, err = ea.Avg(&testCountStruct{}, predicateCount)
+	require.NoError(t, err)
+	require.Equal(t, 2.5, v)
+
+	// matching example and conditions
+	v, err = ea.Sum(&testCountStruct{Name: firstName}, predicateCount, ndgom.Where(ndgom.Gt(predicateCount, 1)))
+	require.NoError(t, err)
+	require.Equal(t, 7.0, v)
+}
+
+func TestEaGroupBy(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add elements with names and counts
+	for i, c := range []int{3, 1, 4, 2} {
+		s := testCountStruct{Name: firstName, Count: c}
+		if i == 3 {
+			s.Name = secondName
+		}
+		err = ea.New(&s)
+		require.NoError(t, err)
+	}
+
+	groups, err := ea.GroupBy(&testCountStruct{}, predicateName, ndgom.Aggregate(predicateCount))
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	require.Equal(t, 3, groups[firstName].Count)
+	require.Equal(t, 1.0, groups[firstName].Min[predicateCount])
+	require.Equal(t, 4.0, groups[firstName].Max[predicateCount])
+	require.Equal(t, 8.0, groups[firstName].Sum[predicateCount])
+	require.Equal(t, 1, groups[secondName].Count)
+}
+
+func TestEaWalk(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add chain of elements: first -> second -> third
+	s1 := testReverseStruct{
+		Name: firstName,
+		Edge: []testReverseStruct{{
+			Type: []string{testType},
+			Name: secondName,
+			Edge: []testReverseStruct{{
+				Type: []string{testType},
+				Name: thirdName,
+			}},
+		}},
+	}
+	err = ea.New(&s1)
+	require.NoError(t, err)
+
+	// walk whole tree
+	var tree testReverseStruct
+	err = ea.Walk(&s1, "Edge", 5, &tree)
+	require.NoError(t, err)
+	require.Equal(t, firstName, tree.Name)
+	require.Len(t, tree.Edge, 1)
+	require.Equal(t, secondName, tree.Edge[0].Name)
+	require.Len(t, tree.Edge[0].Edge, 1)
+	require.Equal(t, thirdName, tree.Edge[0].Edge[0].Name)
+
+	// walk limited by depth
+	var shallow testReverseStruct
+	err = ea.Walk(&s1, "Edge", 2, &shallow)
+	require.NoError(t, err)
+	require.Len(t, shallow.Edge, 1)
+	require.Empty(t, shallow.Edge[0].Edge)
+
+	// walk with language preference, applied in every level
+	l1 := testLangStruct{
+		NameEn: firstName + "En",
+		NameFr: firstName + "Fr",
+		Edge: []testLangStruct{{
+			Type:   []string{testType},
+			NameEn: secondName + "En",
+			NameFr: secondName + "Fr",
+		}},
+	}
+	err = ea.New(&l1)
+	require.NoError(t, err)
+	var langTree testLangStruct
+	err = ea.Walk(&l1, "Edge", 2, &langTree, ndgom.Langs("fr"))
+	require.NoError(t, err)
+	require.Equal(t, firstName+"Fr", langTree.NamePref)
+	require.Len(t, langTree.Edge, 1)
+	require.Equal(t, secondName+"Fr", langTree.Edge[0].NamePref)
+
+	// walked tree can be updated, read only fields are not written in any level
+	langTree.Name = firstName
+	err = ea.Upd(&langTree)
+	require.NoError(t, err)
+	require.Equal(t, firstName, langTree.Name)
+	require.Len(t, langTree.Edge, 1)
+	child := testLangStruct{UID: langTree.Edge[0].UID}
+	err = ea.GetByID(&child)
+	require.NoError(t, err)
+	require.Equal(t, secondName+"En", child.NameEn)
+	require.Equal(t, secondName+"Fr", child.NameFr)
+	require.Equal(t, map[string]string{"en": secondName + "En", "fr": secondName + "Fr"}, child.NameLangs)
+}
+
+func TestEaShortestPath(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add chain of elements: first -> second -> third
+	s1 := testReverseStruct{
+		Name: firstName,
+		Edge: []testReverseStruct{{
+			Type: []string{testType},
+			Name: secondName,
+			Edge: []testReverseStruct{{
+				Type: []string{testType},
+				Name: thirdName,
+			}},
+		}},
+	}
+	err = ea.New(&s1)
+	require.NoError(t, err)
+	s3 := testReverseStruct{Name: thirdName}
+	err = ea.Get(&s3)
+	require.NoError(t, err)
+
+	var path []testReverseStruct
+	_, err = ea.ShortestPath(&s1, &s3, []string{predicateEdge}, &path)
+	require.NoError(t, err)
+	require.Len(t, path, 3)
+	require.Equal(t, firstName, path[0].Name)
+	require.Equal(t, secondName, path[1].Name)
+	require.Equal(t, thirdName, path[2].Name)
+
+	// no path backwards
+	_, err = ea.ShortestPath(&s3, &s1, []string{predicateEdge}, &path)
+	require.ErrorIs(t, err, ndgom.ErrNotExist)
+
+	// path through node of other type
+	other := testOtherStruct{Name: fourthName}
+	err = ea.New(&other)
+	require.NoError(t, err)
+	err = ea.Link(&s3, "Edge", &other)
+	require.NoError(t, err)
+	err = ea.Link(&other, "Edge", &s1)
+	require.NoError(t, err)
+	_, err = ea.ShortestPath(&s3, &s1, []string{predicateEdge}, &path)
+	require.ErrorIs(t, err, ndgom.ErrPathType)
+}
+
+func TestEaShortestPathWeighted(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add elements: first -(1)-> second -(1)-> third, and direct first -(5)-> third
+	s1 := testFacetStruct{
+		Name: firstName,
+		Edge: []*testFacetStruct{{
+			Type:       []string{testType},
+			Name:       secondName,
+			EdgeWeight: 1,
+			Edge: []*testFacetStruct{{
+				UID:        "_:third",
+				Type:       []string{testType},
+				Name:       thirdName,
+				EdgeWeight: 1,
+			}},
+		}, {
+			UID:        "_:third",
+			EdgeWeight: 5,
+		}},
+	}
+	err = ea.New(&s1)
+	require.NoError(t, err)
+	s3 := testFacetStruct{Name: thirdName}
+	err = ea.Get(&s3)
+	require.NoError(t, err)
+
+	// unweighted path is direct, and weight is number of edges
+	var path []testFacetStruct
+	weight, err := ea.ShortestPath(&s1, &s3, []string{predicateEdge}, &path)
+	require.NoError(t, err)
+	require.Len(t, path, 2)
+	require.Equal(t, 1.0, weight)
+
+	// weighted path goes through second
+	weight, err = ea.ShortestPath(&s1, &s3, []string{predicateEdge + "|weight"}, &path)
+	require.NoError(t, err)
+	require.Len(t, path, 3)
+	require.Equal(t, secondName, path[1].Name)
+	require.Equal(t, 2.0, weight)
+}
+
+func TestEaGetAny(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	ndgom.Register(&testStruct{}, &testOtherStruct{})
+	// add elements of different types, with same name
+	s1 := testStruct{Name: firstName, Attr: firstAttr}
+	err = ea.New(&s1)
+	require.NoError(t, err)
+	s2 := testOtherStruct{
+		Name:  firstName,
+		Count: 2,
+		Edge:  []ndgom.Any{{Value: &testStruct{UID: s1.UID}}},
+	}
+	err = ea.New(&s2)
+	require.NoError(t, err)
+
+	// decode each into its registered type
+	nodes, err := ea.GetAny(ndgom.Eq(predicateName, firstName))
+	require.NoError(t, err)
+	require.Len(t, nodes, 2)
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *testStruct:
+			require.Equal(t, s1, *n)
+		case *testOtherStruct:
+			require.Equal(t, s2.UID, n.UID)
+			require.Equal(t, 2, n.Count)
+		default:
+			t.Fatalf("unexpected node type %T", node)
+		}
+	}
+
+	// root functions without shared predicate
+	nodes, err = ea.GetAny(ndgom.UID(s1.UID, s2.UID))
+	require.NoError(t, err)
+	require.Len(t, nodes, 2)
+	nodes, err = ea.GetAny(ndgom.Has(predicateCount))
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	require.Equal(t, s2.UID, nodes[0].(*testOtherStruct).UID)
+	nodes, err = ea.GetAny(ndgom.Type(testType))
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	require.Equal(t, &s1, nodes[0])
+	nodes, err = ea.GetAny(ndgom.Has(predicateName), ndgom.Where(ndgom.Type("TestOtherType")))
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	require.Equal(t, s2.UID, nodes[0].(*testOtherStruct).UID)
+
+	// decode edge into registered type
+	get1 := testOtherStruct{UID: s2.UID}
+	err = ea.GetByID(&get1)
+	require.NoError(t, err)
+	require.Len(t, get1.Edge, 1)
+	require.Equal(t, &s1, get1.Edge[0].Value)
+}
+
+func TestEaUpdClear(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add element with default values
+	s1 := eaAddNewElement(t, dg)
+
+	// clear by Go field name
+	upd1 := testStruct{
+		UID: s1.UID,
+	}
+	err = ea.Upd(&upd1, ndgom.Clear("Attr"))
+	require.NoError(t, err)
+
+	// check if cleared correctly
+	expected := testStruct{
+		UID:  s1.UID,
+		Type: []string{testType},
+		Name: firstName,
+	}
+	require.Exactly(t, expected, upd1)
+	eaValidateIfElementMatchesDatabase(t, dg, &expected)
+
+	// unknown and read only fields, obj is left untouched
+	upd2 := testStruct{UID: s1.UID, Name: "ignoredName"}
+	err = ea.Upd(&upd2, ndgom.Clear("Atr"))
+	require.ErrorIs(t, err, ndgom.ErrUnknownField)
+	require.Equal(t, s1.UID, upd2.UID)
+	upd3 := testReverseStruct{UID: s1.UID}
+	err = ea.Upd(&upd3, ndgom.Clear("~"+predicateEdge))
+	require.ErrorIs(t, err, ndgom.ErrNotWritable)
+	eaValidateIfElementMatchesDatabase(t, dg, &expected)
+
+	// type can't be cleared, by Go field name or predicate, as node wouldn't be found by type anymore
+	upd4 := testStruct{UID: s1.UID}
+	err = ea.Upd(&upd4, ndgom.Clear("Type"))
+	require.ErrorIs(t, err, ndgom.ErrNotWritable)
+	err = ea.Upd(&upd4, ndgom.Clear("dgraph.type"))
+	require.ErrorIs(t, err, ndgom.ErrNotWritable)
+	eaValidateIfElementMatchesDatabase(t, dg, &expected)
+}
+
+func TestEaUpdFields(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add element with default values
+	s1 := eaAddNewElement(t, dg)
+
+	// update only name, even though attr is set
+	upd1 := testStruct{
+		UID:  s1.UID,
+		Name: "updatedName",
+		Attr: "ignoredAttr",
+	}
+	err = ea.UpdFields(&upd1, "Name")
+	require.NoError(t, err)
+
+	// check if updated correctly
+	expected := testStruct{
+		UID:  s1.UID,
+		Type: []string{testType},
+		Name: "updatedName",
+		Attr: firstAttr,
+	}
+	require.Exactly(t, expected, upd1)
+	eaValidateIfElementMatchesDatabase(t, dg, &expected)
+
+	// listed empty field is deleted, by predicate name
+	upd2 := testStruct{
+		UID: s1.UID,
+	}
+	err = ea.UpdFields(&upd2, predicateAttr)
+	require.NoError(t, err)
+	expected.Attr = ""
+	eaValidateIfElementMatchesDatabase(t, dg, &expected)
+
+	// unknown and read only fields
+	upd3 := testStruct{UID: s1.UID, Name: "ignoredName"}
+	err = ea.UpdFields(&upd3, "Nmae")
+	require.ErrorIs(t, err, ndgom.ErrUnknownField)
+	upd4 := testReverseStruct{UID: s1.UID}
+	err = ea.UpdFields(&upd4, "Parents")
+	require.ErrorIs(t, err, ndgom.ErrNotWritable)
+	eaValidateIfElementMatchesDatabase(t, dg, &expected)
+
+	// empty type is not deleted, by Go field name or predicate
+	upd5 := testStruct{UID: s1.UID}
+	err = ea.UpdFields(&upd5, "Type")
+	require.ErrorIs(t, err, ndgom.ErrNotWritable)
+	err = ea.UpdFields(&upd5, "dgraph.type")
+	require.ErrorIs(t, err, ndgom.ErrNotWritable)
+	eaValidateIfElementMatchesDatabase(t, dg, &expected)
+}
+
+func TestEaLink(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add elements
+	s1 := testReverseStruct{Name: firstName}
+	err = ea.New(&s1)
+	require.NoError(t, err)
+	s2 := testReverseStruct{Name: secondName}
+	err = ea.New(&s2)
+	require.NoError(t, err)
+	s3 := testReverseStruct{Name: thirdName}
+	err = ea.New(&s3)
+	require.NoError(t, err)
+
+	// link both to list edge, check via reverse edges
+	err = ea.Link(&s1, "Edge", &s2)
+	require.NoError(t, err)
+	err = ea.Link(&s1, predicateEdge, &s3)
+	require.NoError(t, err)
+	get2 := testReverseStruct{UID: s2.UID}
+	err = ea.GetByID(&get2)
+	require.NoError(t, err)
+	require.Len(t, get2.Parents, 1)
+	require.Equal(t, s1.UID, get2.Parents[0].UID)
+	get3 := testReverseStruct{UID: s3.UID}
+	err = ea.GetByID(&get3)
+	require.NoError(t, err)
+	require.Len(t, get3.Parents, 1)
+
+	// unlink one
+	err = ea.Unlink(&s1, "Edge", &s2)
+	require.NoError(t, err)
+	get2 = testReverseStruct{UID: s2.UID}
+	err = ea.GetByID(&get2)
+	require.NoError(t, err)
+	require.Empty(t, get2.Parents)
+	get3 = testReverseStruct{UID: s3.UID}
+	err = ea.GetByID(&get3)
+	require.NoError(t, err)
+	require.Len(t, get3.Parents, 1)
+
+	// wrong type of other end
+	other := testOtherStruct{UID: s2.UID}
+	err = ea.Link(&s1, "Edge", &other)
+	require.ErrorIs(t, err, ndgom.ErrNotExist)
+
+	// unknown, scalar and reverse fields
+	err = ea.Link(&s1, "Edeg", &s2)
+	require.ErrorIs(t, err, ndgom.ErrUnknownField)
+	err = ea.Link(&s1, "Name", &s2)
+	require.ErrorIs(t, err, ndgom.ErrNotWritable)
+	err = ea.Link(&s1, "~"+predicateEdge, &s2)
+	require.ErrorIs(t, err, ndgom.ErrNotWritable)
+}
+
+func TestEaDel(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add elements, s1 owns s2, which owns s3 and s1 (loop), s4 is not owned
+	s1 := testOwnedStruct{Name: firstName}
+	err = ea.New(&s1)
+	require.NoError(t, err)
+	s2 := testOwnedStruct{Name: secondName}
+	err = ea.New(&s2)
+	require.NoError(t, err)
+	s3 := testOwnedStruct{Name: thirdName}
+	err = ea.New(&s3)
+	require.NoError(t, err)
+	s4 := testOwnedStruct{Name: fourthName}
+	err = ea.New(&s4)
+	require.NoError(t, err)
+	require.NoError(t, ea.Link(&s1, "Edge", &s2))
+	require.NoError(t, ea.Link(&s2, "Edge", &s3))
+	require.NoError(t, ea.Link(&s2, "Edge", &s1))
+
+	// wrong type deletes nothing
+	other := testOtherStruct{UID: s1.UID}
+	err = ea.Del(&other)
+	require.ErrorIs(t, err, ndgom.ErrNotExist)
+	get := testOwnedStruct{UID: s1.UID}
+	err = ea.GetByID(&get)
+	require.NoError(t, err)
+	require.Equal(t, firstName, get.Name)
+
+	// cascade
+	err = ea.Del(&s1)
+	require.NoError(t, err)
+	for _, uid := range []string{s1.UID, s2.UID, s3.UID} {
+		get := testOwnedStruct{UID: uid}
+		err = ea.GetByID(&get)
+		require.NoError(t, err)
+		require.Empty(t, get.Name)
+	}
+	get = testOwnedStruct{UID: s4.UID}
+	err = ea.GetByID(&get)
+	require.NoError(t, err)
+	require.Equal(t, fourthName, get.Name)
+
+	// already deleted
+	err = ea.Del(&s1)
+	require.ErrorIs(t, err, ndgom.ErrNotExist)
+}
+
+func TestAdExportImport(t *testing.T) {
+	for _, format := range []ndgom.ExportFormat{ndgom.ExportJSON, ndgom.ExportRDF} {
+		func() {
+			// pre
+			var err error
+			dg := dgNewClient()
+			defer setupTeardown(dg)()
+			ea.Init(dg, 0)
+			// add element with edge facet, language tagged name, and edge to node of other type
+			s1 := testFacetStruct{
+				Name:       firstName,
+				NameOrigin: "origin",
+				Edge:       []*testFacetStruct{{Type: []string{testType}, Name: secondName, EdgeWeight: 0.5}},
+			}
+			err = ea.New(&s1)
+			require.NoError(t, err)
+			l1 := testLangStruct{Name: thirdName, NameEn: thirdName + "En"}
+			err = ea.New(&l1)
+			require.NoError(t, err)
+			other := testOtherStruct{Name: fourthName}
+			err = ea.New(&other)
+			require.NoError(t, err)
+			err = ea.Link(&s1, "Edge", &other)
+			require.NoError(t, err)
+			// node of both types, with predicate only in the second one
+			both := testNoteStruct{Type: []string{"TestOtherType", testType}, Name: fourthName + "Both", Note: "note"}
+			err = ea.New(&both)
+			require.NoError(t, err)
+
+			// export and import into same db, which creates copies
+			var buf bytes.Buffer
+			err = ndgom.Admin{}.Export(dg, &buf, format, "TestOtherType", testType)
+			require.NoError(t, err)
+			require.Contains(t, buf.String(), s1.UID)
+			err = ndgom.Admin{}.Import(dg, &buf)
+			require.NoError(t, err)
+
+			// copied edge points to copy, with its facet, and edge to not exported node is skipped
+			firsts := []testFacetStruct{{Name: firstName}}
+			err = ea.Get(&firsts)
+			require.NoError(t, err)
+			require.Len(t, firsts, 2)
+			for _, f := range firsts {
+				require.Equal(t, "origin", f.NameOrigin)
+				if f.UID == s1.UID {
+					require.Len(t, f.Edge, 2)
+					continue
+				}
+				require.Len(t, f.Edge, 1)
+				require.Equal(t, secondName, f.Edge[0].Name)
+				require.Equal(t, 0.5, f.Edge[0].EdgeWeight)
+			}
+			others := []testOtherStruct{{Name: fourthName}}
+			err = ea.Get(&others)
+			require.NoError(t, err)
+			require.Len(t, others, 1)
+
+			// node of both types is copied once, with predicates of both types
+			boths := []testNoteStruct{{Name: fourthName + "Both"}}
+			err = ea.Get(&boths)
+			require.NoError(t, err)
+			require.Len(t, boths, 2)
+			for _, b := range boths {
+				require.Equal(t, "note", b.Note)
+				require.ElementsMatch(t, []string{"TestOtherType", testType}, b.Type)
+			}
+
+			// language tagged values are copied
+			langs := []testLangStruct{{NameEn: thirdName + "En"}}
+			err = ea.Get(&langs)
+			require.NoError(t, err)
+			require.Len(t, langs, 2)
+		}()
+	}
+}
+
+func TestAdGetSchema(t *testing.T) {
+	// pre
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+
+	schema, err := ndgom.Admin{}.GetSchema(dg)
+	require.NoError(t, err)
+	name, ok := schema.Predicate(predicateName)
+	require.True(t, ok)
+	require.Equal(t, "string", name.Type)
+	require.True(t, name.Index)
+	require.True(t, name.HasIndex("hash"))
+	require.True(t, name.Upsert)
+	require.True(t, name.Lang)
+	require.False(t, name.List)
+	edge, ok := schema.Predicate(predicateEdge)
+	require.True(t, ok)
+	require.Equal(t, "uid", edge.Type)
+	require.True(t, edge.List)
+	require.True(t, edge.Reverse)
+	require.False(t, edge.Index)
+	_, ok = schema.Predicate("notExistingPredicate")
+	require.False(t, ok)
+
+	typ, ok := schema.Type(testType)
+	require.True(t, ok)
+	require.True(t, typ.HasField(predicateName))
+	require.True(t, typ.HasField(predicateEdge))
+	require.False(t, typ.HasField("notExistingPredicate"))
+}
+
+func TestAdVerifyModels(t *testing.T) {
+	// pre
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+
+	err := ndgom.Admin{}.VerifyModels(dg, &testReverseStruct{}, &testLangStruct{}, &testGeoStruct{}, &testCountStruct{})
+	require.NoError(t, err)
+
+	// single edge to [uid] predicate
+	err = ndgom.Admin{}.VerifyModels(dg, &testStruct{})
+	require.ErrorIs(t, err, ndgom.ErrSchemaMismatch)
+	require.Contains(t, err.Error(), "testStruct.Edge")
+
+	// typo, wrong type, and predicate missing in type
+	type badStruct struct {
+		UID   string   `json:"uid,omitempty"`
+		Type  []string `json:"dgraph.type,omitempty" dgtype:"TestOtherType"`
+		Name  string   `json:"testNme,omitempty"`
+		Count string   `json:"testCount,omitempty"`
+		Attr  string   `json:"testAttribute,omitempty"`
+	}
+	err = ndgom.Admin{}.VerifyModels(dg, &badStruct{})
+	require.ErrorIs(t, err, ndgom.ErrSchemaMismatch)
+	require.Contains(t, err.Error(), "predicate testNme does not exist")
+	require.Contains(t, err.Error(), "badStruct.Count")
+	require.Contains(t, err.Error(), "type TestOtherType misses field testAttribute")
+}
+
+func TestEaGetIndex(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	// add elements
+	s1 := testNoteStruct{Name: firstName, Attr: firstAttr, Count: 1, Note: firstName}
+	err = ea.New(&s1)
+	require.NoError(t, err)
+	s2 := testNoteStruct{Name: firstName, Attr: secondAttr, Count: 2, Note: secondName}
+	err = ea.New(&s2)
+	require.NoError(t, err)
+
+	// indexed name is root, note without index is filter
+	get1 := []testNoteStruct{{Name: firstName, Note: firstName}}
+	err = ea.Get(&get1)
+	require.NoError(t, err)
+	require.Len(t, get1, 1)
+	require.Equal(t, s1.UID, get1[0].UID)
+
+	// term index is usable by eq
+	get2 := []testNoteStruct{{Attr: secondAttr}}
+	err = ea.Get(&get2)
+	require.NoError(t, err)
+	require.Len(t, get2, 1)
+	require.Equal(t, s2.UID, get2[0].UID)
+
+	// note alone can't be root
+	get3 := []testNoteStruct{{Note: firstName}}
+	err = ea.Get(&get3)
+	require.ErrorIs(t, err, ndgom.ErrNoIndex)
+	require.Contains(t, err.Error(), "predicate testNote needs @index(exact or hash or term or fulltext)")
+
+	// same for aggregation and grouping
+	sum, err := ea.Sum(&testNoteStruct{Name: firstName, Note: secondName}, predicateCount)
+	require.NoError(t, err)
+	require.Equal(t, 2.0, sum)
+	_, err = ea.Sum(&testNoteStruct{Note: firstName}, predicateCount)
+	require.ErrorIs(t, err, ndgom.ErrNoIndex)
+	_, err = ea.GroupBy(&testNoteStruct{Note: firstName}, predicateName)
+	require.ErrorIs(t, err, ndgom.ErrNoIndex)
+}
+
+func TestEaObserver(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	ea.Init(dg, 0)
+	var ops []*ndgom.Op
+	ndgom.Admin{}.SetObserver(dg, ndgom.ObserverFunc(func(op *ndgom.Op) {
+		ops = append(ops, op)
+	}))
+	defer ndgom.Admin{}.SetObserver(dg, nil)
+
+	// add and get element
+	s1 := eaAddNewElement(t, dg)
+	get1 := testStruct{Name: firstName}
+	err = ea.Get(&get1)
+	require.NoError(t, err)
+	require.Equal(t, s1.UID, get1.UID)
+
+	// mutation and its commit, then schema lookup of Get and query
+	require.Len(t, ops, 4)
+	require.Equal(t, ndgom.OpMutation, ops[0].Kind)
+	require.Len(t, ops[0].Mutations, 1)
+	require.Contains(t, string(ops[0].Mutations[0].SetJson), firstName)
+	require.NotNil(t, ops[0].Latency)
+	require.Equal(t, ndgom.OpCommit, ops[1].Kind)
+	require.NoError(t, ops[1].Err)
+	require.Equal(t, ndgom.OpQuery, ops[2].Kind)
+	require.Equal(t, "schema {}", ops[2].Query)
+	require.Equal(t, ndgom.OpQuery, ops[3].Kind)
+	require.Contains(t, ops[3].Query, predicateName)
+	require.True(t, ops[3].Duration > 0)
+	require.NotNil(t, ops[3].Latency)
+	require.True(t, ops[3].Size > 0)
+
+	// admin operations are observed too
+	ops = nil
+	_, err = ndgom.Admin{}.GetSchema(dg)
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	require.Equal(t, "schema {}", ops[0].Query)
+
+	// operations of other clients are not
+	ops = nil
+	_, err = ndgom.Admin{}.GetSchema(dgNewClient())
+	require.NoError(t, err)
+	require.Empty(t, ops)
+}
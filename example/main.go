@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -62,7 +63,7 @@ func main() {
 	}
 
 	// add new element
-	txn := ndgo.NewTxnWithoutContext(dg.NewTxn())
+	txn := ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
 	defer txn.Discard()
 	new1 := DbElement{
 		Name: "Test",
@@ -86,7 +87,7 @@ func main() {
 	}
 
 	// query
-	txn = ndgo.NewTxnWithoutContext(dg.NewTxn())
+	txn = ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
 	defer txn.Discard()
 
 	var e []DbElement
@@ -111,7 +112,7 @@ func main() {
 	log.Println(e3)
 
 	// upd
-	txn = ndgo.NewTxnWithoutContext(dg.NewTxn())
+	txn = ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
 	defer txn.Discard()
 
 	upd1 := DbElement{
@@ -130,7 +131,7 @@ func main() {
 
 	log.Println(upd1)
 
-	txn = ndgo.NewTxnWithoutContext(dg.NewTxn())
+	txn = ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
 	defer txn.Discard()
 
 	// Easy{}
@@ -167,4 +168,17 @@ func main() {
 		panic(err)
 	}
 	log.Println(e6)
+
+	// managed transaction
+	err = ndgom.Easy{}.Tx(context.Background(), func(tx *ndgom.Tx) error {
+		var e7 DbElement
+		if err := tx.GetOne("elementName", "Test", &e7); err != nil {
+			return err
+		}
+		e7.Name = "Test7"
+		return tx.Upd(&e7)
+	})
+	if err != nil {
+		panic(err)
+	}
 }
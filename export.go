@@ -0,0 +1,542 @@
+package ndgom
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/dgo"
+	"github.com/dgraph-io/dgo/protos/api"
+	log "github.com/ppp225/lvlog"
+)
+
+// ExportFormat specifies how nodes are written by Admin{}.Export
+type ExportFormat int
+
+const (
+	// ExportJSON writes one json object per node and line, with edges as {"uid":"0x123"}
+	ExportJSON ExportFormat = iota
+	// ExportRDF writes N-Quads, one per line
+	ExportRDF
+)
+
+var (
+	exportPageSize  = 1000
+	importChunkSize = 1000
+)
+
+// Export writes all nodes of given dgraph types to w, reading them page by page in a single read transaction.
+// Nodes have their uid, dgraph.type, and all predicates of given types in schema, with facets and values in all languages.
+// Facets of language tagged values are not exported, only of untagged ones. Nodes with multiple of given types are written once, with predicates of all of them.
+// Edges to nodes, which are not of given types, are skipped, so Import doesn't create empty nodes in their place.
+func (Admin) Export(dg *dgo.Dgraph, w io.Writer, format ExportFormat, types ...string) (err error) {
+	var write func(w io.Writer, node map[string]interface{}) error
+	switch format {
+	case ExportJSON:
+		write = writeJSONNode
+	case ExportRDF:
+		write = writeNQuadNode
+	default:
+		return fmt.Errorf("ndgom.Admin{}.Export: %w", ErrExportFormat)
+	}
+
+	txn := Observe(NewDgoTxn(context.Background(), dg.NewReadOnlyTxn()), observerOf(dg))
+	defer txn.Discard()
+
+	schema, err := getSchema(txn)
+	if err != nil {
+		return err
+	}
+	exported, err := exportUIDs(txn, types)
+	if err != nil {
+		return err
+	}
+	predicates, langFacets, err := exportPredicates(schema, types)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	seen := make(map[string]bool)
+	for _, dgType := range types {
+		after := ""
+		for {
+			nodes, err := exportPage(txn, dgType, predicates, langFacets, after)
+			if err != nil {
+				return err
+			}
+			for _, node := range nodes {
+				uid, _ := node["uid"].(string)
+				after = uid
+				if seen[uid] {
+					continue
+				}
+				seen[uid] = true
+				pruneEdges(node, exported)
+				if err = write(bw, node); err != nil {
+					return err
+				}
+			}
+			if len(nodes) < exportPageSize {
+				break
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// Import reads data written by Admin{}.Export, or any JSON lines or N-Quads with uids or blank nodes, and applies it.
+// Format is detected from the first line. Exported uids are mapped to new nodes, so data can be imported into any database.
+// Data is applied in chunks, each in its own transaction, so on error chunks applied before stay in the database.
+func (Admin) Import(dg *dgo.Dgraph, r io.Reader) (err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	assigned := make(map[string]string) // exported uid or blank node label -> new uid
+	var (
+		rdf   *bool
+		nodes []interface{}
+		quads bytes.Buffer
+		count int
+	)
+	flush := func() error {
+		if count == 0 {
+			return nil
+		}
+		mu := &api.Mutation{}
+		if *rdf {
+			mu.SetNquads = quads.Bytes()
+		} else {
+			jsonBytes, err := json.Marshal(nodes)
+			if err != nil {
+				return err
+			}
+			mu.SetJson = jsonBytes
+		}
+		if err := importChunk(dg, mu, assigned); err != nil {
+			return err
+		}
+		nodes, count = nil, 0
+		quads.Reset()
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if rdf == nil {
+			isRDF := !strings.HasPrefix(line, "{")
+			rdf = &isRDF
+		}
+		if *rdf {
+			quads.WriteString(remapNQuad(line, assigned))
+			quads.WriteByte('\n')
+		} else {
+			dec := json.NewDecoder(strings.NewReader(line))
+			dec.UseNumber()
+			var node interface{}
+			if err = dec.Decode(&node); err != nil {
+				return fmt.Errorf("ndgom.Admin{}.Import: %w", err)
+			}
+			remapJSON(node, assigned)
+			nodes = append(nodes, node)
+		}
+		count++
+		if count >= importChunkSize {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// --------------------------------------- helpers ---------------------------------------
+
+// exportUIDs returns uids of all nodes of given types
+func exportUIDs(txn Txn, types []string) (uids map[string]bool, err error) {
+	uids = make(map[string]bool)
+	for _, dgType := range types {
+		after := ""
+		for {
+			nodes, err := exportPage(txn, dgType, "", "", after)
+			if err != nil {
+				return nil, err
+			}
+			for _, node := range nodes {
+				after, _ = node["uid"].(string)
+				uids[after] = true
+			}
+			if len(nodes) < exportPageSize {
+				break
+			}
+		}
+	}
+	return uids, nil
+}
+
+// exportPredicates returns predicates of all given types to query, with facets and values in all languages.
+// Language tagged predicates are queried as predicate@*, which can't have facets, so facets of their untagged values are returned separately in langFacets.
+func exportPredicates(schema *Schema, types []string) (predicates, langFacets string, err error) {
+	var sb, fb strings.Builder
+	sb.WriteString(" dgraph.type")
+	seen := make(map[string]bool)
+	for _, dgType := range types {
+		st, ok := schema.Type(dgType)
+		if !ok {
+			return "", "", fmt.Errorf("ndgom.Admin{}.Export: type %s does not exist in schema", dgType)
+		}
+		for _, field := range st.Fields {
+			if seen[field.Name] {
+				continue
+			}
+			seen[field.Name] = true
+			ps, ok := schema.Predicate(field.Name)
+			switch {
+			case !ok:
+				return "", "", fmt.Errorf("ndgom.Admin{}.Export: predicate %s of type %s does not exist in schema", field.Name, dgType)
+			case ps.Lang:
+				fmt.Fprintf(&sb, " %s@*", field.Name)
+				fmt.Fprintf(&fb, " %s @facets", field.Name)
+			case ps.Type == "uid":
+				fmt.Fprintf(&sb, " %s @facets { uid }", field.Name)
+			default:
+				fmt.Fprintf(&sb, " %s @facets", field.Name)
+			}
+		}
+	}
+	return sb.String(), fb.String(), nil
+}
+
+// exportPage queries next page of nodes of dgType with predicates next to uid, after uid.
+// If langFacets are set, they are queried in second block of the same page, and facet keys are merged into nodes.
+func exportPage(txn Txn, dgType, predicates, langFacets, after string) (nodes []map[string]interface{}, err error) {
+	params := fmt.Sprintf("first: %d", exportPageSize)
+	if after != "" {
+		params += ", after: " + after
+	}
+	facetsBlock := ""
+	if langFacets != "" {
+		facetsBlock = fmt.Sprintf(`
+	  f(func: type(%s), %s) {
+	    uid%s
+	  }`, dgType, params, langFacets)
+	}
+	q := fmt.Sprintf(`
+	{
+	  q(func: type(%s), %s) {
+	    uid%s
+	  }%s
+	}`, dgType, params, predicates, facetsBlock)
+	resp, err := txn.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Q []map[string]interface{} `json:"q"`
+		F []map[string]interface{} `json:"f"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(resp.GetJson()))
+	dec.UseNumber()
+	if err = dec.Decode(&res); err != nil {
+		return nil, err
+	}
+	facets := make(map[string]map[string]interface{}, len(res.F))
+	for _, f := range res.F {
+		uid, _ := f["uid"].(string)
+		facets[uid] = f
+	}
+	for _, node := range res.Q {
+		uid, _ := node["uid"].(string)
+		for k, v := range facets[uid] {
+			if _, _, isFacet := splitFacet(k); isFacet {
+				node[k] = v
+			}
+		}
+	}
+	return res.Q, nil
+}
+
+// writeJSONNode writes node as single json line
+func writeJSONNode(w io.Writer, node map[string]interface{}) error {
+	jsonBytes, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", jsonBytes)
+	return err
+}
+
+// pruneEdges removes edges of node to nodes, which are not exported
+func pruneEdges(node map[string]interface{}, exported map[string]bool) {
+	isExported := func(v interface{}) bool {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return true
+		}
+		uid, ok := m["uid"].(string)
+		if ok && !exported[uid] {
+			log.Debugf("ndgom.Admin{}.Export: skipping edge of %s to not exported node %s", node["uid"], uid)
+			return false
+		}
+		return true
+	}
+	for k, v := range node {
+		list, ok := v.([]interface{})
+		if !ok {
+			if !isExported(v) {
+				delete(node, k)
+			}
+			continue
+		}
+		kept := list[:0]
+		for _, e := range list {
+			if isExported(e) {
+				kept = append(kept, e)
+			}
+		}
+		if len(kept) == 0 {
+			delete(node, k)
+			continue
+		}
+		node[k] = kept
+	}
+}
+
+// writeNQuadNode writes node as N-Quads, one per predicate value, sorted by predicate.
+// Language tagged keys are written as language tagged literals, and facet keys as facets of their predicate or edge.
+func writeNQuadNode(w io.Writer, node map[string]interface{}) error {
+	subject := "<" + node["uid"].(string) + ">"
+	keys := make([]string, 0, len(node))
+	for k := range node {
+		if _, _, isFacet := splitFacet(k); !isFacet && k != "uid" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		predicate, lang, isLang := splitLang(k)
+		if !isLang {
+			predicate = k
+		}
+		values, isList := node[k].([]interface{})
+		if !isList {
+			values = []interface{}{node[k]}
+		}
+		for i, v := range values {
+			var objects []string
+			var facets map[string]interface{}
+			if m, ok := v.(map[string]interface{}); ok && m["uid"] != nil {
+				// edge facets are in the node the edge points to
+				objects = []string{"<" + m["uid"].(string) + ">"}
+				facets = prefixedFacets(m, k, -1)
+			} else {
+				if s, ok := v.(string); ok && isLang {
+					objects = []string{nquadLiteral(s, "") + "@" + lang}
+				} else {
+					var err error
+					if objects, err = nquadObjects(v); err != nil {
+						return err
+					}
+				}
+				// facets of list values are maps keyed by index
+				index := -1
+				if isList {
+					index = i
+				}
+				facets = prefixedFacets(node, k, index)
+			}
+			for _, o := range objects {
+				if _, err := fmt.Fprintf(w, "%s <%s> %s%s .\n", subject, predicate, o, formatFacets(facets)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// prefixedFacets returns facets of predicate from facet keys of node, i.e. predicate|facet.
+// For list values, index selects value of facet maps keyed by index, otherwise it's -1.
+func prefixedFacets(node map[string]interface{}, predicate string, index int) map[string]interface{} {
+	facets := make(map[string]interface{})
+	for k, v := range node {
+		p, facet, ok := splitFacet(k)
+		if !ok || p != predicate {
+			continue
+		}
+		if m, ok := v.(map[string]interface{}); ok && index >= 0 {
+			if v, ok = m[strconv.Itoa(index)]; !ok {
+				continue
+			}
+		}
+		facets[facet] = v
+	}
+	return facets
+}
+
+// formatFacets returns facets formatted as " (facet=value, ...)", sorted by facet, or "" if there are none
+func formatFacets(facets map[string]interface{}) string {
+	if len(facets) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(facets))
+	for f := range facets {
+		names = append(names, f)
+	}
+	sort.Strings(names)
+	for i, f := range names {
+		switch v := facets[f].(type) {
+		case string:
+			// datetime facets are unquoted
+			if _, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				names[i] = f + "=" + v
+			} else {
+				names[i] = f + "=" + nquadLiteral(v, "")
+			}
+		default:
+			names[i] = f + "=" + fmt.Sprint(v)
+		}
+	}
+	return " (" + strings.Join(names, ", ") + ")"
+}
+
+// nquadObjects returns N-Quad objects of decoded json value, one per list element
+func nquadObjects(v interface{}) (objects []string, err error) {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, e := range v {
+			o, err := nquadObjects(e)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, o...)
+		}
+		return objects, nil
+	case map[string]interface{}:
+		if uid, ok := v["uid"].(string); ok {
+			return []string{"<" + uid + ">"}, nil
+		}
+		// not an edge, so geo json
+		geo, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return []string{nquadLiteral(string(geo), "geo:geojson")}, nil
+	case string:
+		return []string{nquadLiteral(v, "")}, nil
+	case json.Number:
+		if strings.ContainsAny(string(v), ".eE") {
+			return []string{nquadLiteral(string(v), "xs:float")}, nil
+		}
+		return []string{nquadLiteral(string(v), "xs:int")}, nil
+	case bool:
+		return []string{nquadLiteral(fmt.Sprint(v), "xs:boolean")}, nil
+	case nil:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("ndgom.nquadObjects: unsupported value type %T", v)
+}
+
+// nquadLiteral returns quoted N-Quad literal, with optional data type
+func nquadLiteral(s, dataType string) string {
+	quoted, _ := json.Marshal(s) // json string escapes are valid in N-Quads
+	if dataType == "" {
+		return string(quoted)
+	}
+	return string(quoted) + "^^<" + dataType + ">"
+}
+
+// importChunk applies mutation in new transaction, and records uids assigned to blank nodes
+func importChunk(dg *dgo.Dgraph, mu *api.Mutation, assigned map[string]string) (err error) {
+	txn := Observe(NewDgoTxn(context.Background(), dg.NewTxn()), observerOf(dg))
+	defer txn.Discard()
+
+	resp, err := txn.Do(&api.Request{Mutations: []*api.Mutation{mu}})
+	if err != nil {
+		return err
+	}
+	if err = txn.Commit(); err != nil {
+		return err
+	}
+	for label, uid := range resp.GetUids() {
+		assigned[label] = uid
+	}
+	return nil
+}
+
+// remapUID returns uid assigned to exported uid or blank node label in previous chunks, or blank node to create
+func remapUID(label string, assigned map[string]string) (uid string, blank bool) {
+	label = strings.TrimPrefix(label, "_:")
+	if uid, ok := assigned[label]; ok {
+		return uid, false
+	}
+	return "_:" + label, true
+}
+
+// remapJSON remaps uids in decoded json node and its edges
+func remapJSON(v interface{}, assigned map[string]string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if s, ok := val.(string); ok && k == "uid" {
+				v[k], _ = remapUID(s, assigned)
+				continue
+			}
+			remapJSON(val, assigned)
+		}
+	case []interface{}:
+		for _, e := range v {
+			remapJSON(e, assigned)
+		}
+	}
+}
+
+// remapNQuad remaps subject and object nodes of N-Quad line. Predicates and literals are left untouched.
+func remapNQuad(line string, assigned map[string]string) string {
+	subject, rest := cutSpace(line)
+	predicate, rest := cutSpace(rest)
+	// literals may contain whitespace, so only node objects are cut
+	if strings.HasPrefix(rest, "<") || strings.HasPrefix(rest, "_:") {
+		object, tail := cutSpace(rest)
+		rest = remapNQuadNode(object, assigned) + " " + tail
+	}
+	return remapNQuadNode(subject, assigned) + " " + predicate + " " + rest
+}
+
+// remapNQuadNode remaps N-Quad node token, i.e. <0x123> or _:label
+func remapNQuadNode(token string, assigned map[string]string) string {
+	var label string
+	switch {
+	case strings.HasPrefix(token, "<0x") && strings.HasSuffix(token, ">"):
+		label = token[1 : len(token)-1]
+	case strings.HasPrefix(token, "_:"):
+		label = token
+	default:
+		return token
+	}
+	uid, blank := remapUID(label, assigned)
+	if blank {
+		return uid
+	}
+	return "<" + uid + ">"
+}
+
+// cutSpace cuts s around first whitespace, trimming rest
+func cutSpace(s string) (before, after string) {
+	i := strings.IndexAny(s, " \t")
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], strings.TrimLeft(s[i+1:], " \t")
+}
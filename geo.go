@@ -0,0 +1,164 @@
+package ndgom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Geo is a geo value, which can be used in geo queries: GeoPoint or GeoPolygon
+type Geo interface {
+	// coordinates returns value as dgraph function argument, i.e. [lng, lat]
+	coordinates() string
+}
+
+// GeoPoint is a dgraph geo point. Marshals to geo json, i.e. {"type":"Point","coordinates":[lng,lat]}
+// Use *GeoPoint in structs, so omitempty works.
+type GeoPoint struct {
+	Lng float64
+	Lat float64
+}
+
+// GeoPolygon is a dgraph geo polygon. First ring is the outer boundary, others are holes. Rings should be closed.
+// Marshals to geo json, i.e. {"type":"Polygon","coordinates":[[[lng,lat],...]]}
+type GeoPolygon [][]GeoPoint
+
+type geoJSON struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+func (p GeoPoint) pair() [2]float64 {
+	return [2]float64{p.Lng, p.Lat}
+}
+
+func (p GeoPoint) coordinates() string {
+	return "[" + strconv.FormatFloat(p.Lng, 'f', -1, 64) + ", " + strconv.FormatFloat(p.Lat, 'f', -1, 64) + "]"
+}
+
+// MarshalJSON marshals point to geo json
+func (p GeoPoint) MarshalJSON() ([]byte, error) {
+	coords, err := json.Marshal(p.pair())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(geoJSON{Type: "Point", Coordinates: coords})
+}
+
+// UnmarshalJSON unmarshals point from geo json
+func (p *GeoPoint) UnmarshalJSON(data []byte) error {
+	var g geoJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "Point" {
+		return fmt.Errorf("ndgom.GeoPoint: can't unmarshal geo type %q", g.Type)
+	}
+	var pair [2]float64
+	if err := json.Unmarshal(g.Coordinates, &pair); err != nil {
+		return err
+	}
+	p.Lng, p.Lat = pair[0], pair[1]
+	return nil
+}
+
+func (p GeoPolygon) rings() [][][2]float64 {
+	rings := make([][][2]float64, len(p))
+	for i, ring := range p {
+		rings[i] = make([][2]float64, len(ring))
+		for j, point := range ring {
+			rings[i][j] = point.pair()
+		}
+	}
+	return rings
+}
+
+func (p GeoPolygon) coordinates() string {
+	coords, _ := json.Marshal(p.rings()) // can't fail, as it's just floats
+	return string(coords)
+}
+
+// MarshalJSON marshals polygon to geo json
+func (p GeoPolygon) MarshalJSON() ([]byte, error) {
+	coords, err := json.Marshal(p.rings())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(geoJSON{Type: "Polygon", Coordinates: coords})
+}
+
+// UnmarshalJSON unmarshals polygon from geo json
+func (p *GeoPolygon) UnmarshalJSON(data []byte) error {
+	var g geoJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "Polygon" {
+		return fmt.Errorf("ndgom.GeoPolygon: can't unmarshal geo type %q", g.Type)
+	}
+	var rings [][][2]float64
+	if err := json.Unmarshal(g.Coordinates, &rings); err != nil {
+		return err
+	}
+	*p = make(GeoPolygon, len(rings))
+	for i, ring := range rings {
+		(*p)[i] = make([]GeoPoint, len(ring))
+		for j, pair := range ring {
+			(*p)[i][j] = GeoPoint{Lng: pair[0], Lat: pair[1]}
+		}
+	}
+	return nil
+}
+
+// --------------------------------------- Simple ---------------------------------------
+
+// Near makes db query for nodes with geo predicate within distance in meters from point, and unmarshals results as array
+func (Simple) Near(txn Txn, predicate string, point GeoPoint, distance float64, result interface{}, opts ...Option) (err error) {
+	args := point.coordinates() + ", " + strconv.FormatFloat(distance, 'f', -1, 64)
+	return find(txn, Cond{function: "near", predicate: predicate, args: args}, result, newOptions(opts))
+}
+
+// Within makes db query for nodes with geo predicate within polygon, and unmarshals results as array
+func (Simple) Within(txn Txn, predicate string, polygon GeoPolygon, result interface{}, opts ...Option) (err error) {
+	return find(txn, Cond{function: "within", predicate: predicate, args: polygon.coordinates()}, result, newOptions(opts))
+}
+
+// Contains makes db query for nodes with geo predicate containing point or polygon, and unmarshals results as array
+func (Simple) Contains(txn Txn, predicate string, geo Geo, result interface{}, opts ...Option) (err error) {
+	return find(txn, Cond{function: "contains", predicate: predicate, args: geo.coordinates()}, result, newOptions(opts))
+}
+
+// Intersects makes db query for nodes with geo predicate intersecting polygon, and unmarshals results as array
+func (Simple) Intersects(txn Txn, predicate string, polygon GeoPolygon, result interface{}, opts ...Option) (err error) {
+	return find(txn, Cond{function: "intersects", predicate: predicate, args: polygon.coordinates()}, result, newOptions(opts))
+}
+
+// --------------------------------------- Easy ---------------------------------------
+
+// Near makes db query for nodes with geo predicate within distance in meters from point, and populates result slice
+func (Easy) Near(predicate string, point GeoPoint, distance float64, result interface{}, opts ...Option) (err error) {
+	return withReadTxn(func(txn Txn) error {
+		return Simple{}.Near(txn, predicate, point, distance, result, opts...)
+	})
+}
+
+// Within makes db query for nodes with geo predicate within polygon, and populates result slice
+func (Easy) Within(predicate string, polygon GeoPolygon, result interface{}, opts ...Option) (err error) {
+	return withReadTxn(func(txn Txn) error {
+		return Simple{}.Within(txn, predicate, polygon, result, opts...)
+	})
+}
+
+// Contains makes db query for nodes with geo predicate containing point or polygon, and populates result slice
+func (Easy) Contains(predicate string, geo Geo, result interface{}, opts ...Option) (err error) {
+	return withReadTxn(func(txn Txn) error {
+		return Simple{}.Contains(txn, predicate, geo, result, opts...)
+	})
+}
+
+// Intersects makes db query for nodes with geo predicate intersecting polygon, and populates result slice
+func (Easy) Intersects(predicate string, polygon GeoPolygon, result interface{}, opts ...Option) (err error) {
+	return withReadTxn(func(txn Txn) error {
+		return Simple{}.Intersects(txn, predicate, polygon, result, opts...)
+	})
+}
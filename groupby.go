@@ -0,0 +1,139 @@
+package ndgom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GroupKey is a value of grouped by predicate, formatted as string
+type GroupKey string
+
+// Stats holds statistics of a single group. Min, Max, Sum and Avg are keyed by predicates set with Aggregate option.
+type Stats struct {
+	Count int
+	Min   map[string]float64
+	Max   map[string]float64
+	Sum   map[string]float64
+	Avg   map[string]float64
+}
+
+// aggregations used in GroupBy, for each predicate set with Aggregate option
+var groupAggregations = []string{"min", "max", "sum", "avg"}
+
+// Aggregate sets numeric predicates, which are aggregated in each group of GroupBy
+func Aggregate(predicates ...string) Option {
+	return func(o *options) {
+		o.aggregate = append(o.aggregate, predicates...)
+	}
+}
+
+// GroupBy groups nodes matching example type, its populated fields and Where conditions by predicate,
+// and returns count and aggregations set with Aggregate option of each group.
+func (Simple) GroupBy(txn Txn, example interface{}, predicate string, opts ...Option) (groups map[GroupKey]Stats, err error) {
+	if err = validateInput(example); err != nil {
+		return nil, err
+	}
+	o := newOptions(opts)
+	conds, err := matchConds(txn, example, o)
+	if err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	sb.WriteString("count(uid)")
+	for _, p := range o.aggregate {
+		for _, fn := range groupAggregations {
+			fmt.Fprintf(&sb, " %s(%s)", fn, p)
+		}
+	}
+	q := fmt.Sprintf(`
+	{
+	  q(func: %s) %s @groupby(%s) {
+	    %s
+	  }
+	}`, conds[0], filterOf(conds[1:]), predicate, sb.String())
+	resp, err := txn.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Q []struct {
+			GroupBy []map[string]json.RawMessage `json:"@groupby"`
+		} `json:"q"`
+	}
+	if err = json.Unmarshal(resp.GetJson(), &res); err != nil {
+		return nil, err
+	}
+	groups = make(map[GroupKey]Stats)
+	if len(res.Q) == 0 {
+		return groups, nil
+	}
+	for _, group := range res.Q[0].GroupBy {
+		key, err := groupKey(group[predicate])
+		if err != nil {
+			return nil, err
+		}
+		stats, err := groupStats(group, o.aggregate)
+		if err != nil {
+			return nil, err
+		}
+		groups[key] = stats
+	}
+	return groups, nil
+}
+
+// GroupBy groups nodes matching example type, its populated fields and Where conditions by predicate,
+// and returns count and aggregations set with Aggregate option of each group.
+func (Easy) GroupBy(example interface{}, predicate string, opts ...Option) (groups map[GroupKey]Stats, err error) {
+	err = withReadTxn(func(txn Txn) error {
+		groups, err = Simple{}.GroupBy(txn, example, predicate, opts...)
+		return err
+	})
+	return groups, err
+}
+
+// groupKey formats grouped by value as GroupKey. Strings are unquoted, other values are kept as is.
+func groupKey(raw json.RawMessage) (GroupKey, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return GroupKey(s), nil
+	}
+	return GroupKey(raw), nil
+}
+
+// groupStats decodes count and aggregations of single group
+func groupStats(group map[string]json.RawMessage, aggregate []string) (stats Stats, err error) {
+	if err = json.Unmarshal(group["count"], &stats.Count); err != nil {
+		return stats, err
+	}
+	if len(aggregate) == 0 {
+		return stats, nil
+	}
+	stats.Min = make(map[string]float64)
+	stats.Max = make(map[string]float64)
+	stats.Sum = make(map[string]float64)
+	stats.Avg = make(map[string]float64)
+	for _, p := range aggregate {
+		for _, fn := range groupAggregations {
+			raw, ok := group[fmt.Sprintf("%s(%s)", fn, p)]
+			if !ok {
+				continue
+			}
+			var v float64
+			if err = json.Unmarshal(raw, &v); err != nil {
+				return stats, err
+			}
+			switch fn {
+			case "min":
+				stats.Min[p] = v
+			case "max":
+				stats.Max[p] = v
+			case "sum":
+				stats.Sum[p] = v
+			case "avg":
+				stats.Avg[p] = v
+			}
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,108 @@
+package ndgom
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/dgraph-io/dgo/protos/api"
+)
+
+// Link adds edge from node to node, in single upsert checking if both exist and have types of from and to objects.
+// Edge is set by Go field name or predicate of from. For [uid] edges, node is added to the list. From and to need to have UID set.
+func (Simple) Link(txn Txn, from interface{}, edgeField string, to interface{}) (err error) {
+	return link(txn, from, edgeField, to, false)
+}
+
+// Unlink removes edge from node to node, in single upsert checking if both exist and have types of from and to objects.
+// Edge is set by Go field name or predicate of from. For [uid] edges, node is removed from the list. From and to need to have UID set.
+func (Simple) Unlink(txn Txn, from interface{}, edgeField string, to interface{}) (err error) {
+	return link(txn, from, edgeField, to, true)
+}
+
+// Link adds edge from node to node, in single upsert checking if both exist and have types of from and to objects.
+// Edge is set by Go field name or predicate of from. For [uid] edges, node is added to the list. From and to need to have UID set.
+func (Easy) Link(from interface{}, edgeField string, to interface{}) (err error) {
+	return withTxn(func(txn Txn) error {
+		return Simple{}.Link(txn, from, edgeField, to)
+	})
+}
+
+// Unlink removes edge from node to node, in single upsert checking if both exist and have types of from and to objects.
+// Edge is set by Go field name or predicate of from. For [uid] edges, node is removed from the list. From and to need to have UID set.
+func (Easy) Unlink(from interface{}, edgeField string, to interface{}) (err error) {
+	return withTxn(func(txn Txn) error {
+		return Simple{}.Unlink(txn, from, edgeField, to)
+	})
+}
+
+// link adds or removes edge between nodes with upsert
+func link(txn Txn, from interface{}, edgeField string, to interface{}, del bool) (err error) {
+	if err = validateInput(from); err != nil {
+		return err
+	}
+	if err = validateInput(to); err != nil {
+		return err
+	}
+	fromUID, toUID := getUID(from), getUID(to)
+	predicate, err := edgePredicate(reflect.TypeOf(from).Elem(), edgeField)
+	if err != nil {
+		return err
+	}
+	q := fmt.Sprintf(`
+	query {
+	  F as f(func: uid(%s)) %s {
+	    uid
+	  }
+	  T as t(func: uid(%s)) %s {
+	    uid
+	  }
+	}`, fromUID, typeFilter(getDgType(from)), toUID, typeFilter(getDgType(to)))
+	mu := &api.Mutation{Cond: "@if(eq(len(F), 1) AND eq(len(T), 1))"}
+	nquad := []byte(fmt.Sprintf("uid(F) <%s> uid(T) .", predicate))
+	if del {
+		mu.DelNquads = nquad
+	} else {
+		mu.SetNquads = nquad
+	}
+	resp, err := txn.Do(&api.Request{Query: q, Mutations: []*api.Mutation{mu}})
+	if err != nil {
+		return err
+	}
+	// check if both nodes of requested uid/type existed
+	var res struct {
+		F []json.RawMessage `json:"f"`
+		T []json.RawMessage `json:"t"`
+	}
+	if err = json.Unmarshal(resp.GetJson(), &res); err != nil {
+		return err
+	}
+	if len(res.F) == 0 || len(res.T) == 0 {
+		return fmt.Errorf("ndgom.link: %w", ErrNotExist)
+	}
+	return nil
+}
+
+// edgePredicate returns predicate of writable edge field of struct t, set by Go field name or predicate
+func edgePredicate(t reflect.Type, edgeField string) (predicate string, err error) {
+	field, predicate, ok := lookupField(t, edgeField)
+	if !ok {
+		return "", fmt.Errorf("ndgom.link: %w, %s has no field %s", ErrUnknownField, t.Name(), edgeField)
+	}
+	_, isEdge := edgeType(field.Type)
+	if st, _ := structType(field.Type); st == anyType {
+		isEdge = true
+	}
+	if !isEdge || isReverse(predicate) {
+		return "", fmt.Errorf("ndgom.link: %w, %s.%s is not a writable edge", ErrNotWritable, t.Name(), field.Name)
+	}
+	return predicate, nil
+}
+
+// typeFilter returns filter matching nodes of dgraph type, same as used in Stateless{}.Upd. Empty if type is _all_.
+func typeFilter(dgType string) string {
+	if dgType == "_all_" {
+		return ""
+	}
+	return fmt.Sprintf("@filter(eq(dgraph.type, %s))", dgType)
+}
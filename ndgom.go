@@ -7,6 +7,27 @@ import "fmt"
 // easy.go - all abstractions, easiest to use
 // simple.go - a few abstractions, gives control over transactions to user
 // stateless.go - minimal abstractions, gives nearly full control over what's happening
+// observer.go - Observer hooks around db operations, for logging, metrics and tracing
+// tx.go - managed transactions with retries, used via Easy{}.Tx
+// txn.go - Txn interface all APIs work on, with adapters for ndgo and dgo transactions
+// admin.go - Admin{} helpers for administrative uses, like dropping db and migrating schema
+// options.go - Option modifiers of a single query, like Langs, Where and Clear
+// tags.go - struct tag parsing, for facets, language tagged and read only fields
+// where.go - Where conditions, like Eq, Ge and Between
+// order.go - result ordering by order tags and Order options
+// geo.go - geo types and Near, Within, Contains and Intersects queries
+// search.go - term, full-text, regexp and match search
+// aggregate.go - Min, Max, Sum and Avg aggregations
+// groupby.go - GroupBy queries with count and aggregations
+// walk.go - recursive Walk traversal
+// path.go - ShortestPath between nodes
+// registry.go - type registry, for decoding nodes by dgraph.type
+// link.go - Link and Unlink edge operations
+// del.go - Del of nodes together with nodes of owned edges
+// rdf.go - N-Quad encoding and mutations
+// schema.go - live schema queries and cache
+// verify.go - VerifyModels checking model structs against live schema
+// export.go - Export and Import of typed data as JSON lines or N-Quads
 
 // Common Errors
 var (
@@ -16,10 +37,24 @@ var (
 // User Errors
 var (
 	ErrWrongInput = fmt.Errorf("input needs to be *ptr")
+	// ErrSearchMode happens when using unknown SearchMode. Methods: Search
+	ErrSearchMode = fmt.Errorf("unknown search mode")
+	// ErrExportFormat happens when using unknown ExportFormat. Methods: Export
+	ErrExportFormat = fmt.Errorf("unknown export format")
+	// ErrSchemaMismatch happens when model structs don't match live schema. Methods: VerifyModels
+	ErrSchemaMismatch = fmt.Errorf("models don't match schema")
+	// ErrNoIndex happens when no populated field has index needed by eq root function. Methods: Get
+	ErrNoIndex = fmt.Errorf("predicate has no index needed by eq")
+	// ErrPathType happens when path goes through node, which is not of result type. Methods: ShortestPath
+	ErrPathType = fmt.Errorf("path node is not of result type")
+	// ErrUnknownField happens when struct has no field of given Go name or predicate. Methods: Upd with Clear, UpdFields, Link, Unlink
+	ErrUnknownField = fmt.Errorf("struct has no such field")
+	// ErrNotWritable happens when field can't be written on its own, i.e. uid, Type, facet or read only field. Methods: Upd with Clear, UpdFields, Link, Unlink
+	ErrNotWritable = fmt.Errorf("field can't be written on its own")
 )
 
 // Stateless API Errors. Don't need to be handled in higher level APIs
 var (
-	// ErrUpsertUID happens when running upsert with wrong uid set in struct. Methods: Upd
+	// ErrUpsertUID happens when running upsert with wrong uid set in struct or N-Quads. Methods: Upd, UpdRDF
 	ErrUpsertUID = fmt.Errorf("uid of object must be set to uid(U)")
 )
@@ -0,0 +1,162 @@
+package ndgom
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo"
+	"github.com/dgraph-io/dgo/protos/api"
+)
+
+// OpKind is kind of db operation
+type OpKind int
+
+const (
+	// OpQuery is DQL query
+	OpQuery OpKind = iota
+	// OpMutation is mutation without query
+	OpMutation
+	// OpUpsert is query with conditional mutations
+	OpUpsert
+	// OpCommit is transaction commit
+	OpCommit
+)
+
+// Op describes single db operation run by ndgom, as seen by Observer
+type Op struct {
+	Kind      OpKind
+	Query     string          // DQL query, empty for plain mutations
+	Mutations []*api.Mutation // mutations with their json or rdf data and conditions
+	Start     time.Time
+	Duration  time.Duration // time of operation as seen by client, set when finished
+	Latency   *api.Latency  // server side latency reported by dgraph, set when finished, nil on error
+	Size      int           // size of response json in bytes, set when finished
+	Err       error         // set when finished
+}
+
+// Observer is notified around each db operation, i.e. for logging, metrics or tracing.
+// Same *Op is passed to both methods, so it can be used to match them.
+type Observer interface {
+	// Started is called before operation is sent to dgraph
+	Started(op *Op)
+	// Finished is called after operation returned
+	Finished(op *Op)
+}
+
+// ObserverFunc is Observer notified only when operations finish
+type ObserverFunc func(op *Op)
+
+// Started does nothing
+func (f ObserverFunc) Started(op *Op) {}
+
+// Finished calls f
+func (f ObserverFunc) Finished(op *Op) {
+	f(op)
+}
+
+// observers are Observers registered per client
+var observers struct {
+	sync.RWMutex
+	m map[*dgo.Dgraph]Observer
+}
+
+// SetObserver registers observer notified around each operation ndgom runs with client dg:
+// Easy{} transactions, including Tx and ReadTx, if dg is the client set by Easy{}.Init,
+// and Admin{} queries and mutations called with dg, like GetSchema, Export and Import. Set nil to remove it.
+// Simple{} and Stateless{} run on transactions created by the caller, so they are observed only when wrapped with Observe.
+func (Admin) SetObserver(dg *dgo.Dgraph, o Observer) {
+	observers.Lock()
+	defer observers.Unlock()
+	if o == nil {
+		delete(observers.m, dg)
+		return
+	}
+	if observers.m == nil {
+		observers.m = make(map[*dgo.Dgraph]Observer)
+	}
+	observers.m[dg] = o
+}
+
+// observerOf returns Observer registered for client dg, or nil
+func observerOf(dg *dgo.Dgraph) Observer {
+	observers.RLock()
+	defer observers.RUnlock()
+	return observers.m[dg]
+}
+
+// Observe wraps txn, so o is notified around each of its operations.
+// Use it to observe Simple{} and Stateless{} operations, i.e. ndgom.Observe(ndgom.NewNdgoTxn(txn), o).
+func Observe(txn Txn, o Observer) Txn {
+	if o == nil {
+		return txn
+	}
+	return &observedTxn{txn: txn, observer: o}
+}
+
+type observedTxn struct {
+	txn      Txn
+	observer Observer
+}
+
+func (v *observedTxn) Seti(jsonMutations ...interface{}) (*api.Response, error) {
+	var obj interface{} = jsonMutations
+	if len(jsonMutations) == 1 {
+		obj = jsonMutations[0]
+	}
+	jsonBytes, _ := json.Marshal(obj) // only for observer, actual error is returned by txn
+	op := &Op{Kind: OpMutation, Mutations: []*api.Mutation{{SetJson: jsonBytes}}}
+	return v.observe(op, func() (*api.Response, error) {
+		return v.txn.Seti(jsonMutations...)
+	})
+}
+
+func (v *observedTxn) DoSetb(query, cond string, setJSON, setRDF []byte) (*api.Response, error) {
+	op := &Op{Kind: OpUpsert, Query: query, Mutations: []*api.Mutation{{Cond: cond, SetJson: setJSON, SetNquads: setRDF}}}
+	return v.observe(op, func() (*api.Response, error) {
+		return v.txn.DoSetb(query, cond, setJSON, setRDF)
+	})
+}
+
+func (v *observedTxn) Do(req *api.Request) (*api.Response, error) {
+	op := &Op{Kind: OpUpsert, Query: req.Query, Mutations: req.Mutations}
+	switch {
+	case len(req.Mutations) == 0:
+		op.Kind = OpQuery
+	case req.Query == "":
+		op.Kind = OpMutation
+	}
+	return v.observe(op, func() (*api.Response, error) {
+		return v.txn.Do(req)
+	})
+}
+
+func (v *observedTxn) Query(query string) (*api.Response, error) {
+	return v.observe(&Op{Kind: OpQuery, Query: query}, func() (*api.Response, error) {
+		return v.txn.Query(query)
+	})
+}
+
+func (v *observedTxn) Commit() error {
+	_, err := v.observe(&Op{Kind: OpCommit}, func() (*api.Response, error) {
+		return nil, v.txn.Commit()
+	})
+	return err
+}
+
+func (v *observedTxn) Discard() {
+	v.txn.Discard()
+}
+
+// observe runs fn, notifying observer around it
+func (v *observedTxn) observe(op *Op, fn func() (*api.Response, error)) (*api.Response, error) {
+	op.Start = time.Now()
+	v.observer.Started(op)
+	resp, err := fn()
+	op.Duration = time.Since(op.Start)
+	op.Latency = resp.GetLatency()
+	op.Size = len(resp.GetJson())
+	op.Err = err
+	v.observer.Finished(op)
+	return resp, err
+}
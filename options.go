@@ -0,0 +1,47 @@
+package ndgom
+
+// Option modifies a single query, i.e. Easy{}.Get(&result, ndgom.Langs("en", "fr"))
+type Option func(*options)
+
+type options struct {
+	langs         []string
+	matchDistance int
+	conds         []Cond
+	order         []order
+	aggregate     []string
+	loop          bool
+	clear         []string
+}
+
+// Langs sets language preference list for fields tagged with json:"predicate@.".
+// Values are returned in first found language, falling back to any language.
+func Langs(langs ...string) Option {
+	return func(o *options) {
+		o.langs = langs
+	}
+}
+
+// MatchDistance sets maximum Levenshtein distance for Match search
+func MatchDistance(distance int) Option {
+	return func(o *options) {
+		o.matchDistance = distance
+	}
+}
+
+// Clear deletes fields in Upd, even though they are empty in updated object.
+// Fields can be set by Go field names or predicates, i.e. ndgom.Clear("Attr", "testName").
+// Unknown fields make Upd fail with ErrUnknownField, and uid, Type, facet and read only fields with ErrNotWritable.
+func Clear(fields ...string) Option {
+	return func(o *options) {
+		o.clear = append(o.clear, fields...)
+	}
+}
+
+// newOptions applies opts over default options
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
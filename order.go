@@ -0,0 +1,72 @@
+package ndgom
+
+import (
+	"reflect"
+	"strings"
+)
+
+// order is a single ordering key
+type order struct {
+	predicate string
+	desc      bool
+}
+
+// OrderAsc orders query results ascending by predicate. Multiple Order options make multi-key ordering.
+// Overrides default ordering declared with order:"asc" and order:"desc" struct tags.
+func OrderAsc(predicate string) Option {
+	return func(o *options) {
+		o.order = append(o.order, order{predicate: predicate})
+	}
+}
+
+// OrderDesc orders query results descending by predicate. Multiple Order options make multi-key ordering.
+// Overrides default ordering declared with order:"asc" and order:"desc" struct tags.
+func OrderDesc(predicate string) Option {
+	return func(o *options) {
+		o.order = append(o.order, order{predicate: predicate, desc: true})
+	}
+}
+
+// parseTagOrder gets default ordering of struct t from order tags, with keys in field declaration order
+func parseTagOrder(t reflect.Type) (keys []order) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("order")
+		if !ok {
+			continue
+		}
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+		switch tag {
+		case "asc":
+			keys = append(keys, order{predicate: name})
+		case "desc":
+			keys = append(keys, order{predicate: name, desc: true})
+		default:
+			panic("order tag should be asc or desc, but is: " + tag)
+		}
+	}
+	return keys
+}
+
+// orderParams returns root function ordering params of result, i.e. ", orderasc: name, orderdesc: age".
+// Order options take precedence over order tags.
+func orderParams(result interface{}, o *options) string {
+	keys := o.order
+	if len(keys) == 0 && result != nil {
+		if t, ok := structType(reflect.TypeOf(result)); ok {
+			keys = parseTagOrder(t)
+		}
+	}
+	var sb strings.Builder
+	for _, key := range keys {
+		if key.desc {
+			sb.WriteString(", orderdesc: " + key.predicate)
+		} else {
+			sb.WriteString(", orderasc: " + key.predicate)
+		}
+	}
+	return sb.String()
+}
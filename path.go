@@ -0,0 +1,121 @@
+package ndgom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ShortestPath finds shortest path between nodes, traversing any of the edge predicates, and unmarshals nodes on it in order into result slice.
+// Edges can be weighted with facets, using the json tag convention, i.e. "predicate|facet". Returns total weight of the path.
+// Returns ErrNotExist if there is no path, and ErrPathType if path goes through node, which is not of result type.
+func (Simple) ShortestPath(txn Txn, fromUID, toUID string, predicates []string, result interface{}, opts ...Option) (weight float64, err error) {
+	if err = validateInput(result); err != nil {
+		return 0, err
+	}
+	o := newOptions(opts)
+	edges := make([]string, len(predicates))
+	for i, p := range predicates {
+		if p, facet, ok := splitFacet(p); ok {
+			edges[i] = fmt.Sprintf("%s @facets(%s)", p, facet)
+			continue
+		}
+		edges[i] = p
+	}
+	dgType := getDgType(result)
+	filter := ""
+	if dgType != "_all_" {
+		filter = filterOf([]Cond{{function: "type", args: dgType}})
+	}
+	q := fmt.Sprintf(`
+	{
+	  path as shortest(from: %s, to: %s) {
+	    %s
+	  }
+	  q(func: uid(path)) %s {
+	    %s
+	    expand(%s)
+	  }
+	}`, fromUID, toUID, strings.Join(edges, "\n\t    "), filter, getPredicates(result, o), dgType)
+	resp, err := txn.Query(q)
+	if err != nil {
+		return 0, err
+	}
+	var res struct {
+		Path  []map[string]interface{} `json:"_path_"`
+		Nodes []json.RawMessage        `json:"q"`
+	}
+	if err = json.Unmarshal(resp.GetJson(), &res); err != nil {
+		return 0, err
+	}
+	if len(res.Path) == 0 {
+		return 0, fmt.Errorf("ndgom.Simple{}.ShortestPath: %w", ErrNotExist)
+	}
+	weight, _ = res.Path[0]["_weight_"].(float64)
+
+	// nodes are returned in uid order, so sort them in order of path
+	nodes := make(map[string]json.RawMessage, len(res.Nodes))
+	for _, raw := range res.Nodes {
+		var node struct {
+			UID string `json:"uid"`
+		}
+		if err = json.Unmarshal(raw, &node); err != nil {
+			return 0, err
+		}
+		nodes[node.UID] = raw
+	}
+	uids := pathUIDs(res.Path[0], predicates)
+	ordered := make([]json.RawMessage, len(uids))
+	for i, uid := range uids {
+		raw, ok := nodes[uid]
+		if !ok {
+			return 0, fmt.Errorf("ndgom.Simple{}.ShortestPath: %w, node %s is not %s", ErrPathType, uid, dgType)
+		}
+		ordered[i] = raw
+	}
+	data, err := json.Marshal(ordered)
+	if err != nil {
+		return 0, err
+	}
+	return weight, newDecoder(result, o)(data, &result)
+}
+
+// ShortestPath finds shortest path between from and to, traversing any of the edge predicates, and unmarshals nodes on it in order into result slice.
+// Edges can be weighted with facets, using the json tag convention, i.e. "predicate|facet". Returns total weight of the path.
+// Returns ErrNotExist if there is no path, and ErrPathType if path goes through node, which is not of result type.
+// From and to need to have UID set.
+func (Easy) ShortestPath(from, to interface{}, predicates []string, result interface{}, opts ...Option) (weight float64, err error) {
+	fromUID, toUID := getUID(from), getUID(to)
+	err = withReadTxn(func(txn Txn) error {
+		weight, err = Simple{}.ShortestPath(txn, fromUID, toUID, predicates, result, opts...)
+		return err
+	})
+	return weight, err
+}
+
+// pathUIDs returns uids of nested _path_ node, in order of traversal
+func pathUIDs(node map[string]interface{}, predicates []string) (uids []string) {
+	for node != nil {
+		uid, _ := node["uid"].(string)
+		uids = append(uids, uid)
+		next := node
+		node = nil
+		for _, p := range predicates {
+			if pred, _, ok := splitFacet(p); ok {
+				p = pred
+			}
+			switch child := next[p].(type) {
+			case map[string]interface{}:
+				node = child
+			case []interface{}:
+				if len(child) > 0 {
+					node, _ = child[0].(map[string]interface{})
+				}
+			}
+			if node != nil {
+				break
+			}
+		}
+	}
+	return uids
+}
@@ -0,0 +1,199 @@
+package ndgom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// MarshalNQuads encodes obj as N-Quads, following the same struct tags as json mutations.
+// Subject is the uid field: <0x123> for uid, _:name for blank node or uid(U) for Stateless{}.UpdRDF. Nodes without uid get generated blank nodes.
+// Edges are encoded recursively, with facets declared in the struct they point to, and language tagged fields with their language.
+// Read only fields are skipped, as are empty fields tagged omitempty.
+func MarshalNQuads(obj interface{}) ([]byte, error) {
+	e := &nquadEncoder{}
+	if _, err := e.encode(reflect.ValueOf(obj)); err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+// nquadEncoder writes N-Quads of nodes into buf
+type nquadEncoder struct {
+	buf   bytes.Buffer
+	blank int
+}
+
+// encode writes N-Quads of node v, which is a struct, pointer to it or Any, and returns its subject
+func (e *nquadEncoder) encode(v reflect.Value) (subject string, err error) {
+	v, ok := nodeValue(v)
+	if !ok {
+		return "", fmt.Errorf("ndgom.MarshalNQuads: can't encode %s, need struct", v.Kind())
+	}
+	t := v.Type()
+	subject = e.subject(v)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonName(field)
+		if !ok || name == "uid" || isReadOnly(name) {
+			continue
+		}
+		// facets are written with their predicate
+		if _, _, ok := splitFacet(name); ok {
+			continue
+		}
+		f := v.Field(i)
+		if f.IsZero() && strings.Contains(field.Tag.Get("json"), ",omitempty") {
+			continue
+		}
+		if et, _ := structType(field.Type); et == anyType {
+			err = e.encodeEdges(subject, name, f)
+		} else if _, ok := edgeType(field.Type); ok {
+			err = e.encodeEdges(subject, name, f)
+		} else {
+			err = e.encodeValues(subject, name, v, f)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return subject, nil
+}
+
+// encodeEdges writes edges of predicate from subject to nodes in f, which is a node, slice of nodes or pointer to them
+func (e *nquadEncoder) encodeEdges(subject, predicate string, f reflect.Value) (err error) {
+	for f.Kind() == reflect.Ptr {
+		if f.IsNil() {
+			return nil
+		}
+		f = f.Elem()
+	}
+	nodes := []reflect.Value{f}
+	if f.Kind() == reflect.Slice {
+		nodes = make([]reflect.Value, f.Len())
+		for i := range nodes {
+			nodes[i] = f.Index(i)
+		}
+	}
+	for _, node := range nodes {
+		object, err := e.encode(node)
+		if err != nil {
+			return err
+		}
+		// edge facets are declared in the struct the edge points to
+		node, _ = nodeValue(node)
+		facets, err := nquadFacets(node, predicate)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&e.buf, "%s <%s> %s%s .\n", subject, predicate, object, facets)
+	}
+	return nil
+}
+
+// encodeValues writes scalar values of field f of node v, with facets declared as its sibling fields
+func (e *nquadEncoder) encodeValues(subject, name string, v, f reflect.Value) (err error) {
+	predicate := name
+	var objects []string
+	if p, lang, ok := splitLang(name); ok {
+		if f.Kind() != reflect.String {
+			return fmt.Errorf("ndgom.MarshalNQuads: language tagged field %s must be string", name)
+		}
+		predicate = p
+		objects = []string{nquadLiteral(f.String(), "") + "@" + lang}
+	} else {
+		jsonBytes, err := json.Marshal(f.Interface())
+		if err != nil {
+			return err
+		}
+		dec := json.NewDecoder(bytes.NewReader(jsonBytes))
+		dec.UseNumber()
+		var val interface{}
+		if err = dec.Decode(&val); err != nil {
+			return err
+		}
+		if objects, err = nquadObjects(val); err != nil {
+			return err
+		}
+	}
+	facets, err := nquadFacets(v, name)
+	if err != nil {
+		return err
+	}
+	for _, o := range objects {
+		fmt.Fprintf(&e.buf, "%s <%s> %s%s .\n", subject, predicate, o, facets)
+	}
+	return nil
+}
+
+// subject returns subject of node v based on its uid field, generating blank node if it's empty
+func (e *nquadEncoder) subject(v reflect.Value) string {
+	var uid string
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if name, ok := jsonName(t.Field(i)); ok && name == "uid" {
+			uid = v.Field(i).String()
+			break
+		}
+	}
+	switch {
+	case uid == "":
+		e.blank++
+		return fmt.Sprintf("_:ndgom%d", e.blank)
+	case strings.HasPrefix(uid, "_:") || strings.HasPrefix(uid, "uid("):
+		return uid
+	default:
+		return "<" + uid + ">"
+	}
+}
+
+// nodeValue returns struct value of node v, dereferencing pointers, interfaces and Any
+func nodeValue(v reflect.Value) (reflect.Value, bool) {
+	if !v.IsValid() {
+		return v, false
+	}
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	if v.Type() == anyType {
+		return nodeValue(reflect.ValueOf(v.Interface().(Any).Value))
+	}
+	return v, v.Kind() == reflect.Struct
+}
+
+// nquadFacets returns facets of predicate declared in node v, formatted as " (facet=value, ...)", or "" if there are none
+func nquadFacets(v reflect.Value, predicate string) (string, error) {
+	var facets []string
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, ok := jsonName(t.Field(i))
+		if !ok {
+			continue
+		}
+		p, facet, ok := splitFacet(name)
+		if !ok || p != predicate || v.Field(i).IsZero() {
+			continue
+		}
+		val := v.Field(i).Interface()
+		// datetime facets are unquoted
+		if tm, ok := val.(time.Time); ok {
+			facets = append(facets, facet+"="+tm.Format(time.RFC3339Nano))
+			continue
+		}
+		jsonBytes, err := json.Marshal(val)
+		if err != nil {
+			return "", err
+		}
+		facets = append(facets, facet+"="+string(jsonBytes))
+	}
+	if len(facets) == 0 {
+		return "", nil
+	}
+	return " (" + strings.Join(facets, ", ") + ")", nil
+}
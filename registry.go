@@ -0,0 +1,145 @@
+package ndgom
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/ppp225/ndgo"
+)
+
+// registry maps dgraph.type names to registered model struct types
+var registry = struct {
+	sync.RWMutex
+	types map[string]reflect.Type
+}{types: make(map[string]reflect.Type)}
+
+// Register registers model structs by their dgtype tag, so nodes can be decoded into them based on dgraph.type.
+// Used by GetAny, VisitAny and Any edges. Panics if model has no dgtype tag.
+// Usage: ndgom.Register(&Person{}, &Company{})
+func Register(models ...interface{}) {
+	registry.Lock()
+	defer registry.Unlock()
+	for _, model := range models {
+		t, ok := structType(reflect.TypeOf(model))
+		if !ok {
+			panic(fmt.Sprintf("ndgom.Register: model must be a struct, but is: %T", model))
+		}
+		dgType := parseTagDgType(t)
+		if dgType == "_all_" {
+			panic(fmt.Sprintf("ndgom.Register: model %s must have Type field with dgtype tag", t.Name()))
+		}
+		registry.types[dgType] = t
+	}
+}
+
+// registeredType returns first registered struct type of dgraph types
+func registeredType(dgTypes []string) (reflect.Type, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	for _, dgType := range dgTypes {
+		if t, ok := registry.types[dgType]; ok {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// decodeNode decodes node into pointer to new struct of its registered type.
+// Nodes of unregistered types are decoded as map[string]interface{}.
+func decodeNode(data []byte) (interface{}, error) {
+	var node struct {
+		Type []string `json:"dgraph.type"`
+	}
+	if err := json.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	t, ok := registeredType(node.Type)
+	if !ok {
+		var m map[string]interface{}
+		err := json.Unmarshal(data, &m)
+		return m, err
+	}
+	v := reflect.New(t).Interface()
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Any is an edge to node of any registered type, see Register.
+// Value holds pointer to struct of registered type, or map[string]interface{} if type of node isn't registered.
+type Any struct {
+	Value interface{}
+}
+
+var anyType = reflect.TypeOf(Any{})
+
+// MarshalJSON marshals Value
+func (a Any) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Value)
+}
+
+// UnmarshalJSON decodes node into its registered type
+func (a *Any) UnmarshalJSON(data []byte) (err error) {
+	a.Value, err = decodeNode(data)
+	return err
+}
+
+// --------------------------------------- Simple ---------------------------------------
+
+// GetAny makes db query with root function, filtered by Where conditions, and returns nodes of any type.
+// Each node is decoded into pointer to its registered type, see Register.
+func (Simple) GetAny(txn Txn, root Cond, opts ...Option) (nodes []interface{}, err error) {
+	err = Simple{}.VisitAny(txn, root, func(node interface{}) error {
+		nodes = append(nodes, node)
+		return nil
+	}, opts...)
+	return nodes, err
+}
+
+// VisitAny makes db query with root function, filtered by Where conditions, and calls visit with each node of any type.
+// Each node is decoded into pointer to its registered type, see Register. Stops at first error returned by visit.
+func (Simple) VisitAny(txn Txn, root Cond, visit func(node interface{}) error, opts ...Option) (err error) {
+	o := newOptions(opts)
+	q := rootQuery(root.function, root.predicate, root.args, orderParams(nil, o), filterOf(o.conds), defaultPredicates, "_all_")
+	resp, err := txn.Query(string(q))
+	if err != nil {
+		return err
+	}
+	var raws []json.RawMessage
+	if err = json.Unmarshal(ndgo.Unsafe{}.FlattenRespToArray(resp.GetJson()), &raws); err != nil {
+		return err
+	}
+	for _, raw := range raws {
+		node, err := decodeNode(raw)
+		if err != nil {
+			return err
+		}
+		if err = visit(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// --------------------------------------- Easy ---------------------------------------
+
+// GetAny makes db query with root function, filtered by Where conditions, and returns nodes of any type.
+// Each node is decoded into pointer to its registered type, see Register.
+func (Easy) GetAny(root Cond, opts ...Option) (nodes []interface{}, err error) {
+	err = withReadTxn(func(txn Txn) error {
+		nodes, err = Simple{}.GetAny(txn, root, opts...)
+		return err
+	})
+	return nodes, err
+}
+
+// VisitAny makes db query with root function, filtered by Where conditions, and calls visit with each node of any type.
+// Each node is decoded into pointer to its registered type, see Register. Stops at first error returned by visit.
+func (Easy) VisitAny(root Cond, visit func(node interface{}) error, opts ...Option) (err error) {
+	return withReadTxn(func(txn Txn) error {
+		return Simple{}.VisitAny(txn, root, visit, opts...)
+	})
+}
@@ -0,0 +1,187 @@
+package ndgom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dgraph-io/dgo"
+)
+
+// Schema is the live database schema, as returned by Admin{}.GetSchema
+type Schema struct {
+	Predicates []SchemaPredicate `json:"schema"`
+	Types      []SchemaType      `json:"types"`
+}
+
+// SchemaPredicate is schema of a single predicate
+type SchemaPredicate struct {
+	Predicate string   `json:"predicate"`
+	Type      string   `json:"type"`                // scalar type or uid, i.e. string, int, datetime, geo
+	Index     bool     `json:"index,omitempty"`     // has @index
+	Tokenizer []string `json:"tokenizer,omitempty"` // indexes, i.e. hash, term, fulltext
+	Upsert    bool     `json:"upsert,omitempty"`
+	Reverse   bool     `json:"reverse,omitempty"`
+	List      bool     `json:"list,omitempty"` // [type]
+	Lang      bool     `json:"lang,omitempty"`
+	Count     bool     `json:"count,omitempty"`
+}
+
+// SchemaType is schema of a single dgraph type
+type SchemaType struct {
+	Name   string        `json:"name"`
+	Fields []SchemaField `json:"fields"`
+}
+
+// SchemaField is a predicate of dgraph type
+type SchemaField struct {
+	Name string `json:"name"`
+}
+
+// GetSchema queries schema of all predicates and types.
+// schema {} returns both, same as schema(type: ...) for each type would.
+func (Admin) GetSchema(dg *dgo.Dgraph) (schema *Schema, err error) {
+	txn := Observe(NewDgoTxn(context.Background(), dg.NewReadOnlyTxn()), observerOf(dg))
+	defer txn.Discard()
+
+	return getSchema(txn)
+}
+
+// getSchema queries schema of all predicates and types in txn
+func getSchema(txn Txn) (schema *Schema, err error) {
+	resp, err := txn.Query("schema {}")
+	if err != nil {
+		return nil, err
+	}
+	schema = &Schema{}
+	if err = json.Unmarshal(resp.GetJson(), schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// Predicate returns schema of predicate by name
+func (s *Schema) Predicate(name string) (SchemaPredicate, bool) {
+	for _, p := range s.Predicates {
+		if p.Predicate == name {
+			return p, true
+		}
+	}
+	return SchemaPredicate{}, false
+}
+
+// Type returns schema of dgraph type by name
+func (s *Schema) Type(name string) (SchemaType, bool) {
+	for _, t := range s.Types {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return SchemaType{}, false
+}
+
+// HasField checks if dgraph type has predicate
+func (t SchemaType) HasField(predicate string) bool {
+	for _, f := range t.Fields {
+		if f.Name == predicate {
+			return true
+		}
+	}
+	return false
+}
+
+// HasIndex checks if predicate has index of tokenizer, i.e. hash
+func (p SchemaPredicate) HasIndex(tokenizer string) bool {
+	for _, t := range p.Tokenizer {
+		if t == tokenizer {
+			return true
+		}
+	}
+	return false
+}
+
+// --------------------------------------- cache ---------------------------------------
+
+// schemaCache caches schema for queries by example, loaded on first use.
+// Reset by Easy{}.Init, Easy{}.ResetSchemaCache, Admin{}.DropDB and Admin{}.MigrateSchema.
+var schemaCache struct {
+	sync.Mutex
+	schema *Schema
+}
+
+// cachedSchema returns cached schema, querying it in txn if not cached yet
+func cachedSchema(txn Txn) (*Schema, error) {
+	schemaCache.Lock()
+	defer schemaCache.Unlock()
+	if schemaCache.schema == nil {
+		schema, err := getSchema(txn)
+		if err != nil {
+			return nil, err
+		}
+		schemaCache.schema = schema
+	}
+	return schemaCache.schema, nil
+}
+
+// resetSchemaCache drops cached schema, so it's queried again on next use
+func resetSchemaCache() {
+	schemaCache.Lock()
+	schemaCache.schema = nil
+	schemaCache.Unlock()
+}
+
+// eqTokenizers are indexes usable by eq root function, by predicate type
+var eqTokenizers = map[string][]string{
+	"string":   {"exact", "hash", "term", "fulltext"},
+	"default":  {"exact", "hash", "term", "fulltext"},
+	"int":      {"int"},
+	"float":    {"float"},
+	"bool":     {"bool"},
+	"datetime": {"year", "month", "day", "hour"},
+}
+
+// rootPredicate returns first populated field predicate, in sorted order, which has index needed by eq root function.
+// Returns ErrNoIndex naming fields and needed indexes if there's none.
+func rootPredicate(txn Txn, fields map[string]string) (predicate string, err error) {
+	schema, err := cachedSchema(txn)
+	if err != nil {
+		return "", err
+	}
+	var missing []string
+	for _, k := range sortedKeys(fields) {
+		p := k
+		if base, _, ok := splitLang(k); ok {
+			p = base
+		}
+		ps, ok := schema.Predicate(p)
+		if !ok {
+			missing = append(missing, fmt.Sprintf("predicate %s does not exist in schema", p))
+			continue
+		}
+		tokenizers, ok := eqTokenizers[ps.Type]
+		if !ok {
+			missing = append(missing, fmt.Sprintf("predicate %s of type %s can't be used in eq", p, ps.Type))
+			continue
+		}
+		for _, t := range tokenizers {
+			if ps.HasIndex(t) {
+				return k, nil
+			}
+		}
+		missing = append(missing, fmt.Sprintf("predicate %s needs @index(%s)", p, strings.Join(tokenizers, " or ")))
+	}
+	return "", fmt.Errorf("ndgom.rootPredicate: %w: %s", ErrNoIndex, strings.Join(missing, ", "))
+}
+
+// sortedKeys returns keys of m in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,77 @@
+package ndgom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SearchMode is a dgraph string search function, used in Search methods
+type SearchMode string
+
+// Search modes. Each requires matching index on searched predicate
+const (
+	// AnyOfTerms matches any of the terms in text. Requires term index.
+	AnyOfTerms SearchMode = "anyofterms"
+	// AllOfTerms matches all of the terms in text. Requires term index.
+	AllOfTerms SearchMode = "allofterms"
+	// AnyOfText full-text matches any of the words in text, with stemming and stop words. Requires fulltext index.
+	AnyOfText SearchMode = "anyoftext"
+	// AllOfText full-text matches all of the words in text, with stemming and stop words. Requires fulltext index.
+	AllOfText SearchMode = "alloftext"
+	// Regexp matches regular expression, i.e. "^abc" or "/^abc/i" with flags. Requires trigram index.
+	Regexp SearchMode = "regexp"
+	// Match fuzzy matches text within distance set by MatchDistance option. Requires trigram index.
+	Match SearchMode = "match"
+)
+
+// defaultMatchDistance is used for Match search, when MatchDistance option isn't set
+const defaultMatchDistance = 8
+
+// searchArgs returns search function arguments, which follow searched predicate
+func searchArgs(mode SearchMode, text string, o *options) (args string, err error) {
+	switch mode {
+	case AnyOfTerms, AllOfTerms, AnyOfText, AllOfText:
+		return strconv.Quote(text), nil
+	case Regexp:
+		if !strings.HasPrefix(text, "/") {
+			text = "/" + text + "/"
+		}
+		return text, nil
+	case Match:
+		distance := defaultMatchDistance
+		if o.matchDistance > 0 {
+			distance = o.matchDistance
+		}
+		return strconv.Quote(text) + ", " + strconv.Itoa(distance), nil
+	default:
+		return "", fmt.Errorf("ndgom.searchArgs: %w: %q", ErrSearchMode, mode)
+	}
+}
+
+// Search makes db query with search function on predicate and unmarshals results as array
+func (Stateless) Search(txn Txn, predicate string, mode SearchMode, text, dgTypes string, result interface{}) (err error) {
+	args, err := searchArgs(mode, text, newOptions(nil))
+	if err != nil {
+		return err
+	}
+	return get(txn, string(mode), predicate, args, "", "", dgTypes, defaultPredicates, json.Unmarshal, result)
+}
+
+// Search makes type filtered db query with search function on predicate and unmarshals results as array
+func (Simple) Search(txn Txn, predicate string, mode SearchMode, text string, result interface{}, opts ...Option) (err error) {
+	o := newOptions(opts)
+	args, err := searchArgs(mode, text, o)
+	if err != nil {
+		return err
+	}
+	return find(txn, Cond{function: string(mode), predicate: predicate, args: args}, result, o)
+}
+
+// Search makes type filtered db query with search function on predicate and populates result slice
+func (Easy) Search(predicate string, mode SearchMode, text string, result interface{}, opts ...Option) (err error) {
+	return withReadTxn(func(txn Txn) error {
+		return Simple{}.Search(txn, predicate, mode, text, result, opts...)
+	})
+}
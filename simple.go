@@ -1,10 +1,10 @@
 package ndgom
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
-
-	"github.com/ppp225/ndgo"
+	"strings"
 )
 
 // Simple groups Simple{}.API methods.
@@ -12,40 +12,35 @@ import (
 type Simple struct{}
 
 // GetByID makes db query by uid and unmarshals result as object
-func (Simple) GetByID(txn *ndgo.Txn, uid string, result interface{}) (err error) {
+func (Simple) GetByID(txn Txn, uid string, result interface{}, opts ...Option) (err error) {
 	if err = validateInput(result); err != nil {
 		return err
 	}
 	dgType := getDgType(result)
-	return Stateless{}.GetByID(txn, uid, dgType, result)
+	o := newOptions(opts)
+	return getByID(txn, uid, dgType, getPredicates(result, o), newDecoder(result, o), result)
 }
 
 // Get makes db query and unmarshals results as array
-func (Simple) Get(txn *ndgo.Txn, predicate, value string, result interface{}) (err error) {
-	if err = validateInput(result); err != nil {
-		return err
-	}
-	dgType := getDgType(result)
-	return Stateless{}.Get(txn, predicate, value, dgType, result)
+func (Simple) Get(txn Txn, predicate, value string, result interface{}, opts ...Option) (err error) {
+	return find(txn, Cond{function: "eq", predicate: predicate, args: value}, result, newOptions(opts))
 }
 
 // GetOne makes db query and unmarshals first result as object
-func (Simple) GetOne(txn *ndgo.Txn, predicate, value string, result interface{}) (err error) {
-	if err = validateInput(result); err != nil {
-		return err
-	}
-	dgType := getDgType(result)
-	return Stateless{}.GetOne(txn, predicate, value, dgType, result)
+func (Simple) GetOne(txn Txn, predicate, value string, result interface{}, opts ...Option) (err error) {
+	return findOne(txn, Cond{function: "eq", predicate: predicate, args: value}, result, newOptions(opts))
 }
 
 // New creates new node. Do not set UID or Type.
 // Can set Type if multiple needed.
-func (Simple) New(txn *ndgo.Txn, obj interface{}) (err error) {
+func (Simple) New(txn Txn, obj interface{}) (err error) {
 	if err = validateInput(obj); err != nil {
 		return err
 	}
 	setFieldsForNew("new", obj)
+	restore := hideReadOnlyFields(obj)
 	uidMap, err := Stateless{}.New(txn, obj)
+	restore()
 	if err != nil {
 		return err
 	}
@@ -53,18 +48,112 @@ func (Simple) New(txn *ndgo.Txn, obj interface{}) (err error) {
 	return nil
 }
 
-// Upd updates node based on uid and changed fields, and unmarshals updated result into supplied obj
-func (Simple) Upd(txn *ndgo.Txn, obj interface{}) (err error) {
+// Upd updates node based on uid and changed fields, and unmarshals updated result into supplied obj.
+// Empty fields are not updated, unless set with Clear option, which deletes them. Options like Langs apply to the updated result.
+func (Simple) Upd(txn Txn, obj interface{}, opts ...Option) (err error) {
 	if err = validateInput(obj); err != nil {
 		return err
 	}
 	dgType := getDgType(obj)
+	o := newOptions(opts)
+	clear, err := clearPredicates(obj, o)
+	if err != nil {
+		return err
+	}
 	uid := updGetUIDSetUID(obj)
-	err = Stateless{}.Upd(txn, uid, dgType, obj)
+	restore := hideReadOnlyFields(obj)
+	err = Stateless{}.Upd(txn, uid, dgType, obj, clear...)
+	restore()
 	if err != nil {
 		return err
 	}
-	return Stateless{}.GetByID(txn, uid, dgType, obj)
+	return getByID(txn, uid, dgType, getPredicates(obj, o), newDecoder(obj, o), obj)
+}
+
+// UpdFields updates only listed fields of node, set by Go field names or predicates, and unmarshals updated result into supplied obj.
+// Other fields are left untouched, even if they are not empty. Listed fields, which are empty, are deleted.
+func (Simple) UpdFields(txn Txn, obj interface{}, fields ...string) (err error) {
+	if err = validateInput(obj); err != nil {
+		return err
+	}
+	dgType := getDgType(obj)
+	restore := hideReadOnlyFields(obj)
+	partial, clear, err := selectFields(obj, fields)
+	restore()
+	if err != nil {
+		return err
+	}
+	uid := updGetUIDSetUID(obj)
+	err = Stateless{}.Upd(txn, uid, dgType, partial, clear...)
+	if err != nil {
+		return err
+	}
+	o := newOptions(nil)
+	return getByID(txn, uid, dgType, getPredicates(obj, o), newDecoder(obj, o), obj)
+}
+
+// find makes type filtered db query with root function, filtered by Where conditions and ordered by Order options or tags, and unmarshals results as array
+func find(txn Txn, root Cond, result interface{}, o *options) (err error) {
+	if err = validateInput(result); err != nil {
+		return err
+	}
+	dgType := getDgType(result)
+	return get(txn, root.function, root.predicate, root.args, orderParams(result, o), filterOf(o.conds), dgType, getPredicates(result, o), newDecoder(result, o), result)
+}
+
+// findOne makes type filtered db query with root function, filtered by Where conditions and ordered by Order options or tags, and unmarshals first result as object
+func findOne(txn Txn, root Cond, result interface{}, o *options) (err error) {
+	if err = validateInput(result); err != nil {
+		return err
+	}
+	dgType := getDgType(result)
+	return getOne(txn, root.function, root.predicate, root.args, orderParams(result, o), filterOf(o.conds), dgType, getPredicates(result, o), newDecoder(result, o), result)
+}
+
+// clearPredicates returns predicates of fields set with Clear option. Errors for unknown and not writable fields, see writablePredicate.
+func clearPredicates(obj interface{}, o *options) (predicates []string, err error) {
+	t := reflect.TypeOf(obj).Elem()
+	for _, field := range o.clear {
+		predicate, err := writablePredicate(t, field)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, predicate)
+	}
+	return predicates, nil
+}
+
+// selectFields returns json object of obj with uid set to uid(U) for Stateless{}.Upd, and only the listed fields, set by Go field names or predicates, with their facets.
+// Listed fields, which are empty in obj, are returned as predicates to clear. Errors for unknown and not writable fields, see writablePredicate.
+func selectFields(obj interface{}, fields []string) (partial map[string]json.RawMessage, clear []string, err error) {
+	jsonBytes, err := json.Marshal(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	var all map[string]json.RawMessage
+	if err = json.Unmarshal(jsonBytes, &all); err != nil {
+		return nil, nil, err
+	}
+	t := reflect.TypeOf(obj).Elem()
+	partial = map[string]json.RawMessage{"uid": json.RawMessage(`"uid(U)"`)}
+	for _, field := range fields {
+		predicate, err := writablePredicate(t, field)
+		if err != nil {
+			return nil, nil, err
+		}
+		v, ok := all[predicate]
+		if !ok {
+			clear = append(clear, predicate)
+			continue
+		}
+		partial[predicate] = v
+		for k, v := range all {
+			if strings.HasPrefix(k, predicate+"|") {
+				partial[k] = v
+			}
+		}
+	}
+	return partial, clear, nil
 }
 
 func validateInput(obj interface{}) error {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/dgraph-io/dgo/protos/api"
 	"github.com/ppp225/ndgo"
 )
 
@@ -15,42 +16,69 @@ import (
 type Stateless struct{}
 
 // GetByID makes db query by uid and unmarshals result as object
-func (Stateless) GetByID(txn *ndgo.Txn, uid, dgTypes string, result interface{}) (err error) {
-	resp, err := ndgo.Query{}.GetUIDExpandType("q", "uid", uid, "", "", "uid dgraph.type", dgTypes).Run(txn)
+func (Stateless) GetByID(txn Txn, uid, dgTypes string, result interface{}) (err error) {
+	return getByID(txn, uid, dgTypes, defaultPredicates, json.Unmarshal, result)
+}
+
+// getByID is Stateless{}.GetByID with custom predicates to query next to expand(_all_) and custom decoder
+func getByID(txn Txn, uid, dgTypes, predicates string, dec decoder, result interface{}) (err error) {
+	q := ndgo.Query{}.GetUIDExpandType("q", "uid", uid, "", "", predicates, dgTypes)
+	resp, err := txn.Query(string(q))
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal(ndgo.Unsafe{}.FlattenRespToObject(resp.GetJson()), &result)
+	return dec(ndgo.Unsafe{}.FlattenRespToObject(resp.GetJson()), &result)
 }
 
 // Get makes db query and unmarshals results as array
-func (Stateless) Get(txn *ndgo.Txn, predicate, value, dgTypes string, result interface{}) (err error) {
-	resp, err := ndgo.Query{}.GetPredExpandType("q", "eq", predicate, value, "", "", "uid dgraph.type", dgTypes).Run(txn)
+func (Stateless) Get(txn Txn, predicate, value, dgTypes string, result interface{}) (err error) {
+	return get(txn, "eq", predicate, value, "", "", dgTypes, defaultPredicates, json.Unmarshal, result)
+}
+
+// get is Stateless{}.Get with custom root function, its params, filter, predicates to query next to expand(_all_) and custom decoder
+func get(txn Txn, function, predicate, value, params, filter, dgTypes, predicates string, dec decoder, result interface{}) (err error) {
+	q := rootQuery(function, predicate, value, params, filter, predicates, dgTypes)
+	resp, err := txn.Query(string(q))
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal(ndgo.Unsafe{}.FlattenRespToArray(resp.GetJson()), &result)
+	return dec(ndgo.Unsafe{}.FlattenRespToArray(resp.GetJson()), &result)
+}
+
+// rootQuery returns query with root function of predicate and value, or only of value for functions without predicate, like uid, has or type
+func rootQuery(function, predicate, value, params, filter, predicates, dgTypes string) ndgo.QueryDQL {
+	if predicate == "" {
+		return ndgo.Query{}.GetUIDExpandType("q", function, value, params, filter, predicates, dgTypes)
+	}
+	return ndgo.Query{}.GetPredExpandType("q", function, predicate, value, params, filter, predicates, dgTypes)
 }
 
 // GetOne makes db query and unmarshals first result as object
-func (Stateless) GetOne(txn *ndgo.Txn, predicate, value, dgTypes string, result interface{}) (err error) {
-	resp, err := ndgo.Query{}.GetPredExpandType("q", "eq", predicate, value, ",first:1", "", "uid dgraph.type", dgTypes).Run(txn)
+func (Stateless) GetOne(txn Txn, predicate, value, dgTypes string, result interface{}) (err error) {
+	return getOne(txn, "eq", predicate, value, "", "", dgTypes, defaultPredicates, json.Unmarshal, result)
+}
+
+// getOne is Stateless{}.GetOne with custom root function, its params, filter, predicates to query next to expand(_all_) and custom decoder
+func getOne(txn Txn, function, predicate, value, params, filter, dgTypes, predicates string, dec decoder, result interface{}) (err error) {
+	q := rootQuery(function, predicate, value, ",first:1"+params, filter, predicates, dgTypes)
+	resp, err := txn.Query(string(q))
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal(ndgo.Unsafe{}.FlattenRespToObject(resp.GetJson()), &result)
+	return dec(ndgo.Unsafe{}.FlattenRespToObject(resp.GetJson()), &result)
 }
 
 // New creates new node and returns uid map of created node(s)
-func (Stateless) New(txn *ndgo.Txn, obj interface{}) (uidMap map[string]string, err error) {
+func (Stateless) New(txn Txn, obj interface{}) (uidMap map[string]string, err error) {
 	resp, err := txn.Seti(obj)
 	return resp.GetUids(), err
 }
 
 // Upd updates node of specified uid.
 // Updated object should have set uid to `uid(U)`. Actual uid to update should be in the method.
+// Predicates in clear are deleted from the node, in the same upsert.
 // Doesn't result in complete updated object! (like Stateless{}.Get/New does)
-func (Stateless) Upd(txn *ndgo.Txn, uid, dgTypes string, obj interface{}) (err error) {
+func (Stateless) Upd(txn Txn, uid, dgTypes string, obj interface{}, clear ...string) (err error) {
 	jsonBytes, err := json.Marshal(obj)
 	if err != nil {
 		return err
@@ -59,6 +87,46 @@ func (Stateless) Upd(txn *ndgo.Txn, uid, dgTypes string, obj interface{}) (err e
 	if !bytes.Contains(jsonBytes, []byte(`"uid":"uid(U)"`)) {
 		return fmt.Errorf("ndgom.Stateless{}.Upd: %w", ErrUpsertUID)
 	}
+	existed, err := upd(txn, uid, dgTypes, jsonBytes, nil, clear)
+	if err != nil {
+		return err
+	}
+	if !existed {
+		return fmt.Errorf("ndgom.Stateless{}.Upd: %w", ErrNotExist)
+	}
+	return nil
+}
+
+// NewRDF creates new node(s) from N-Quads and returns uid map of created node(s), keyed by blank node labels.
+// Use MarshalNQuads to encode structs.
+func (Stateless) NewRDF(txn Txn, nquads []byte) (uidMap map[string]string, err error) {
+	resp, err := txn.Do(&api.Request{
+		Mutations: []*api.Mutation{{SetNquads: nquads}},
+	})
+	return resp.GetUids(), err
+}
+
+// UpdRDF updates node of specified uid with N-Quads.
+// N-Quads should have subject `uid(U)`. Actual uid to update should be in the method.
+// Predicates in clear are deleted from the node, in the same upsert.
+func (Stateless) UpdRDF(txn Txn, uid, dgTypes string, nquads []byte, clear ...string) (err error) {
+	// check if nquads have uid set to correctly work with upsert
+	if !bytes.Contains(nquads, []byte("uid(U)")) {
+		return fmt.Errorf("ndgom.Stateless{}.UpdRDF: %w", ErrUpsertUID)
+	}
+	existed, err := upd(txn, uid, dgTypes, nil, nquads, clear)
+	if err != nil {
+		return err
+	}
+	if !existed {
+		return fmt.Errorf("ndgom.Stateless{}.UpdRDF: %w", ErrNotExist)
+	}
+	return nil
+}
+
+// upd runs upsert updating node of uid, if it has type dgTypes, with json or rdf set mutation and deletion of predicates in clear.
+// existed is false if node of requested uid/type wasn't found, so nothing was updated.
+func upd(txn Txn, uid, dgTypes string, setJSON, setRDF []byte, clear []string) (existed bool, err error) {
 	// construct upsert
 	q := fmt.Sprintf(`
 	query {
@@ -70,14 +138,33 @@ func (Stateless) Upd(txn *ndgo.Txn, uid, dgTypes string, obj interface{}) (err e
 	}`, uid, dgTypes)
 	// only update if uid of specified type found
 	cond := "@if(eq(len(U), 1))"
-	resp, err := txn.DoSetb(q, cond, jsonBytes, nil)
+	var resp *api.Response
+	if len(clear) == 0 {
+		resp, err = txn.DoSetb(q, cond, setJSON, setRDF)
+	} else {
+		resp, err = txn.Do(&api.Request{
+			Query: q,
+			Mutations: []*api.Mutation{{
+				Cond:      cond,
+				SetJson:   setJSON,
+				SetNquads: setRDF,
+				DelNquads: clearNquads("uid(U)", clear),
+			}},
+		})
+	}
 	if err != nil {
-		return err
+		return false, err
 	}
 	// check if obj of requested uid/type existed. If it didn't, query result will be "q":[{}]
 	existingObj := ndgo.Unsafe{}.FlattenRespToObject(resp.GetJson())
-	if len(existingObj) == 2 {
-		return fmt.Errorf("ndgom.Stateless{}.Upd: %w", ErrNotExist)
+	return len(existingObj) != 2, nil
+}
+
+// clearNquads returns rdf deleting all values of predicates of subject
+func clearNquads(subject string, predicates []string) []byte {
+	var b bytes.Buffer
+	for _, p := range predicates {
+		fmt.Fprintf(&b, "%s <%s> * .\n", subject, p)
 	}
-	return nil
+	return b.Bytes()
 }
@@ -1,6 +1,7 @@
 package ndgom_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/dgraph-io/dgo"
@@ -11,7 +12,7 @@ import (
 
 func slAddNewElement(t *testing.T, dg *dgo.Dgraph) (uid string) {
 	var err error
-	txn := ndgo.NewTxnWithoutContext(dg.NewTxn())
+	txn := ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
 	defer txn.Discard()
 
 	s := testStruct{
@@ -35,7 +36,7 @@ func slAddNewElement(t *testing.T, dg *dgo.Dgraph) (uid string) {
 // makes expected.UID database query and checks if actual == expected
 func slValidateIfElementMatchesDatabase(t *testing.T, dg *dgo.Dgraph, expected *testStruct) {
 	var err error
-	txn := ndgo.NewTxnWithoutContext(dg.NewTxn())
+	txn := ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
 	defer txn.Discard()
 
 	actual := testStruct{}
@@ -53,7 +54,7 @@ func TestSlUpd(t *testing.T) {
 	uid1 := slAddNewElement(t, dg)
 
 	// update one field
-	txn := ndgo.NewTxnWithoutContext(dg.NewTxn())
+	txn := ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
 	defer txn.Discard()
 
 	upd1 := testStruct{
@@ -84,7 +85,7 @@ func TestSlUpdErr(t *testing.T) {
 	uid1 := slAddNewElement(t, dg)
 
 	// ErrUpsertUID
-	txn := ndgo.NewTxnWithoutContext(dg.NewTxn())
+	txn := ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
 	defer txn.Discard()
 	upd1 := testStruct{
 		UID:  "0x123",
@@ -94,7 +95,7 @@ func TestSlUpdErr(t *testing.T) {
 	require.ErrorIs(t, err, ndgom.ErrUpsertUID)
 
 	// ErrNotExist
-	txn = ndgo.NewTxnWithoutContext(dg.NewTxn())
+	txn = ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
 	defer txn.Discard()
 	upd2 := testStruct{
 		UID:  "uid(U)",
@@ -104,13 +105,129 @@ func TestSlUpdErr(t *testing.T) {
 	require.ErrorIs(t, err, ndgom.ErrNotExist)
 
 	// marshal err
-	txn = ndgo.NewTxnWithoutContext(dg.NewTxn())
+	txn = ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
 	defer txn.Discard()
 	err = ndgom.Stateless{}.Upd(txn, uid1, testType, make(chan int))
 	require.Errorf(t, err, "json: unsupported type: chan int")
 
-	txn = ndgo.NewTxnWithoutContext(dg.NewTxn())
+	txn = ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
 	txn.Discard()
 	err = ndgom.Stateless{}.Upd(txn, uid1, testType, upd2)
 	require.ErrorIs(t, err, dgo.ErrFinished)
 }
+
+func TestSlDgoTxn(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	// add element with raw dgo txn
+	txn := ndgom.NewDgoTxn(context.Background(), dg.NewTxn())
+	defer txn.Discard()
+	s := testStruct{
+		UID:  "_:new",
+		Type: []string{testType},
+		Name: firstName,
+		Attr: firstAttr,
+	}
+	uidMap, err := ndgom.Stateless{}.New(txn, &s)
+	require.NoError(t, err)
+	err = txn.Commit()
+	require.NoError(t, err)
+
+	// check if added correctly
+	expected := testStruct{
+		UID:  uidMap["new"],
+		Type: []string{testType},
+		Name: firstName,
+		Attr: firstAttr,
+	}
+	slValidateIfElementMatchesDatabase(t, dg, &expected)
+}
+
+func TestSlUpdClear(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	// add element with default values
+	uid1 := slAddNewElement(t, dg)
+
+	// update one field and clear other
+	txn := ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
+	defer txn.Discard()
+
+	upd1 := testStruct{
+		UID:  "uid(U)",
+		Name: "updatedName",
+	}
+	err = ndgom.Stateless{}.Upd(txn, uid1, testType, &upd1, predicateAttr)
+	require.NoError(t, err)
+	err = txn.Commit()
+	require.NoError(t, err)
+
+	// check if updated correctly
+	expected := testStruct{
+		UID:  uid1,
+		Type: []string{testType},
+		Name: "updatedName",
+	}
+	slValidateIfElementMatchesDatabase(t, dg, &expected)
+}
+
+func TestSlRDF(t *testing.T) {
+	// pre
+	var err error
+	dg := dgNewClient()
+	defer setupTeardown(dg)()
+	// add element with edge from encoded struct
+	txn := ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
+	defer txn.Discard()
+	s := testStruct{
+		UID:  "_:new",
+		Type: []string{testType},
+		Name: firstName,
+		Attr: firstAttr,
+		Edge: &testStruct{
+			UID:  "_:edge",
+			Type: []string{testType},
+			Name: secondName,
+		},
+	}
+	nquads, err := ndgom.MarshalNQuads(&s)
+	require.NoError(t, err)
+	uidMap, err := ndgom.Stateless{}.NewRDF(txn, nquads)
+	require.NoError(t, err)
+	err = txn.Commit()
+	require.NoError(t, err)
+
+	// check if added correctly
+	expected := testStruct{
+		UID:  uidMap["edge"],
+		Type: []string{testType},
+		Name: secondName,
+	}
+	slValidateIfElementMatchesDatabase(t, dg, &expected)
+
+	// update one field and clear other
+	txn = ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
+	defer txn.Discard()
+	nquads, err = ndgom.MarshalNQuads(&testStruct{UID: "uid(U)", Name: "updatedName"})
+	require.NoError(t, err)
+	err = ndgom.Stateless{}.UpdRDF(txn, uidMap["new"], testType, nquads, predicateAttr)
+	require.NoError(t, err)
+	err = txn.Commit()
+	require.NoError(t, err)
+
+	actual := testStruct{}
+	txn = ndgom.NewNdgoTxn(ndgo.NewTxnWithoutContext(dg.NewTxn()))
+	defer txn.Discard()
+	err = ndgom.Stateless{}.GetByID(txn, uidMap["new"], testType, &actual)
+	require.NoError(t, err)
+	require.Equal(t, "updatedName", actual.Name)
+	require.Empty(t, actual.Attr)
+
+	// subject must be uid(U)
+	err = ndgom.Stateless{}.UpdRDF(txn, uidMap["new"], testType, []byte(`<`+uidMap["new"]+`> <testName> "x" .`))
+	require.ErrorIs(t, err, ndgom.ErrUpsertUID)
+}
@@ -0,0 +1,382 @@
+package ndgom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// This file groups struct tag parsing helpers, used to construct queries which can't be expressed with expand(_all_) alone.
+// Supported json tag conventions:
+// json:"predicate|facet" - facet of sibling scalar predicate, or facet of edge if field is in the struct the edge points to
+// json:"~predicate" - reverse edge, requires @reverse in schema. Is read only, so never written in mutations
+// json:"predicate@en" - language tagged value, requires @lang in schema
+// json:"predicate@en:fr:." - value in first found language of the list. Is read only
+// json:"predicate@." - value in language preferred by Langs option, falling back to any language. Is read only
+// json:"predicate@*" - values in all languages, field must be map[string]string keyed by language, "" for untagged. Is read only
+// Fields of type Any, []Any or *Any are edges to nodes of any registered type, see Register
+// Other supported tags:
+// order:"asc" or order:"desc" - default ordering of query results, see order.go
+// owned:"true" - edge to nodes owned by this one, which are deleted with it, see del.go
+
+// defaultPredicates are always queried, next to expand(_all_)
+const defaultPredicates = "uid dgraph.type"
+
+// jsonName returns predicate name from json tag of given field
+func jsonName(field reflect.StructField) (name string, ok bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return "", false
+	}
+	name = strings.Split(tag, ",")[0]
+	if name == "" || name == "-" {
+		return "", false
+	}
+	return name, true
+}
+
+// splitFacet splits json name of facet field into predicate and facet. ok is false when name is not a facet.
+func splitFacet(name string) (predicate, facet string, ok bool) {
+	i := strings.Index(name, "|")
+	if i <= 0 || i == len(name)-1 {
+		return "", "", false
+	}
+	return name[:i], name[i+1:], true
+}
+
+// isReverse checks if predicate name is a reverse edge
+func isReverse(name string) bool {
+	return strings.HasPrefix(name, "~")
+}
+
+// splitLang splits json name of language tagged field into predicate and language. ok is false when name is not language tagged.
+func splitLang(name string) (predicate, lang string, ok bool) {
+	i := strings.Index(name, "@")
+	if i <= 0 || i == len(name)-1 {
+		return "", "", false
+	}
+	return name[:i], name[i+1:], true
+}
+
+// isSingleLang checks if lang is a single language, like en, as opposed to list or wildcard
+func isSingleLang(lang string) bool {
+	return !strings.ContainsAny(lang, ":.*")
+}
+
+// langPredicate returns language tagged predicate to query, applying language preference of options
+func langPredicate(predicate, lang string, o *options) string {
+	if lang == "." && len(o.langs) > 0 {
+		return predicate + "@" + strings.Join(o.langs, ":") + ":."
+	}
+	return predicate + "@" + lang
+}
+
+// langFilterPredicate returns language tagged predicate usable in functions, which accept at most one language
+func langFilterPredicate(predicate, lang string, o *options) string {
+	switch {
+	case isSingleLang(lang):
+		return predicate + "@" + lang
+	case lang == "." && len(o.langs) > 0:
+		return predicate + "@" + o.langs[0]
+	case lang == "." || lang == "*":
+		return predicate
+	default: // list, use first language
+		return predicate + "@" + strings.Split(lang, ":")[0]
+	}
+}
+
+// isReadOnly checks if predicate name can't be written in mutations
+func isReadOnly(name string) bool {
+	if isReverse(name) {
+		return true
+	}
+	_, lang, ok := splitLang(name)
+	return ok && !isSingleLang(lang)
+}
+
+// structType returns struct type of obj, which can be *struct, *[]struct, struct or []struct, or pointers to them
+func structType(t reflect.Type) (reflect.Type, bool) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// isScalarType checks if type t decodes itself, like time.Time or GeoPoint, so it's a scalar value and not an edge
+func isScalarType(t reflect.Type) bool {
+	return t.Implements(unmarshalerType) || reflect.PtrTo(t).Implements(unmarshalerType)
+}
+
+// edgeType returns struct type the field of type t points to. ok is false for scalar fields.
+func edgeType(t reflect.Type) (reflect.Type, bool) {
+	if isScalarType(t) {
+		return t, false
+	}
+	t, ok := structType(t)
+	if !ok || isScalarType(t) {
+		return t, false
+	}
+	return t, true
+}
+
+// facetsOf returns facets declared in struct t for given predicate
+func facetsOf(t reflect.Type, predicate string) (facets []string) {
+	for i := 0; i < t.NumField(); i++ {
+		name, ok := jsonName(t.Field(i))
+		if !ok {
+			continue
+		}
+		if p, f, ok := splitFacet(name); ok && p == predicate {
+			facets = append(facets, f)
+		}
+	}
+	return facets
+}
+
+// lookupField returns struct field with given Go name or predicate, and its predicate. ok is false if there's no such field.
+func lookupField(t reflect.Type, name string) (field reflect.StructField, predicate string, ok bool) {
+	if field, ok := t.FieldByName(name); ok {
+		if predicate, ok := jsonName(field); ok {
+			return field, predicate, true
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if predicate, ok := jsonName(t.Field(i)); ok && predicate == name {
+			return t.Field(i), predicate, true
+		}
+	}
+	return reflect.StructField{}, "", false
+}
+
+// writablePredicate returns predicate of struct field with given Go name or predicate, which can be set or deleted on its own.
+// Returns ErrUnknownField for unknown fields, and ErrNotWritable for uid, dgraph.type, facets and read only fields.
+// dgraph.type is never cleared, as nodes without it are not found by type filtered queries anymore.
+func writablePredicate(t reflect.Type, name string) (predicate string, err error) {
+	field, predicate, ok := lookupField(t, name)
+	if !ok {
+		return "", fmt.Errorf("ndgom.writablePredicate: %w, %s has no field %s", ErrUnknownField, t.Name(), name)
+	}
+	_, _, isFacet := splitFacet(predicate)
+	if predicate == "uid" || predicate == "dgraph.type" || isFacet || isReadOnly(predicate) {
+		return "", fmt.Errorf("ndgom.writablePredicate: %w, %s.%s", ErrNotWritable, t.Name(), field.Name)
+	}
+	return predicate, nil
+}
+
+// getPredicates returns predicates to query for given object, based on its struct tags.
+// Result always contains defaultPredicates, and can be used with expand(_all_).
+func getPredicates(obj interface{}, o *options) string {
+	t, ok := structType(reflect.TypeOf(obj))
+	if !ok {
+		return defaultPredicates
+	}
+	return defaultPredicates + parsePredicates(t, o)
+}
+
+// parsePredicates returns predicates of struct t, which need to be queried explicitly, each prefixed with space
+func parsePredicates(t reflect.Type, o *options) string {
+	var sb strings.Builder
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+		if _, _, ok := splitFacet(name); ok {
+			continue
+		}
+		// edges to any registered type are not expanded by expand(_all_)
+		if t, _ := structType(field.Type); t == anyType {
+			fmt.Fprintf(&sb, " %s { %s expand(_all_) }", name, defaultPredicates)
+			continue
+		}
+		// edge facets are declared in the struct the edge points to
+		if et, ok := edgeType(field.Type); ok {
+			// reverse edges are not part of expand(_all_), so they're always queried
+			facets := facetsOf(et, name)
+			switch {
+			case len(facets) > 0:
+				fmt.Fprintf(&sb, " %s @facets(%s) { %s expand(_all_) }", name, strings.Join(facets, ", "), defaultPredicates)
+			case isReverse(name):
+				fmt.Fprintf(&sb, " %s { %s expand(_all_) }", name, defaultPredicates)
+			}
+			continue
+		}
+		// language tagged values are not part of expand(_all_)
+		if p, lang, ok := splitLang(name); ok {
+			fmt.Fprintf(&sb, " %s", langPredicate(p, lang, o))
+			continue
+		}
+		// scalar facets are declared as sibling fields
+		if facets := facetsOf(t, name); len(facets) > 0 {
+			fmt.Fprintf(&sb, " %s @facets(%s)", name, strings.Join(facets, ", "))
+		}
+	}
+	return sb.String()
+}
+
+// hideReadOnlyFields zeroes read only fields of obj and of nodes reachable through its edges, like reverse edges, so they aren't written in mutations.
+// Returned restore func sets them back to their original values.
+func hideReadOnlyFields(obj interface{}) (restore func()) {
+	var saved []reflect.Value // pairs of field and its original value
+	visited := make(map[uintptr]bool)
+	var hide func(v reflect.Value)
+	hide = func(v reflect.Value) {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return
+			}
+			if v.Kind() == reflect.Ptr {
+				if visited[v.Pointer()] {
+					return
+				}
+				visited[v.Pointer()] = true
+			}
+			v = v.Elem()
+		}
+		if !v.IsValid() {
+			return
+		}
+		if v.Type() == anyType {
+			hide(reflect.ValueOf(v.Interface().(Any).Value))
+			return
+		}
+		if v.Kind() != reflect.Struct || !v.CanSet() {
+			return
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, ok := jsonName(t.Field(i))
+			if !ok {
+				continue
+			}
+			f := v.Field(i)
+			if isReadOnly(name) {
+				val := reflect.New(f.Type()).Elem()
+				val.Set(f)
+				saved = append(saved, f, val)
+				f.Set(reflect.Zero(f.Type()))
+				continue
+			}
+			if _, isEdge := edgeType(f.Type()); !isEdge {
+				if st, _ := structType(f.Type()); st != anyType {
+					continue
+				}
+			}
+			hideEdges(f, hide)
+		}
+	}
+	hide(reflect.ValueOf(obj))
+	return func() {
+		for i := len(saved) - 2; i >= 0; i -= 2 {
+			saved[i].Set(saved[i+1])
+		}
+	}
+}
+
+// hideEdges calls hide for every node of edge field f, which is a node, slice of nodes or pointer to them
+func hideEdges(f reflect.Value, hide func(v reflect.Value)) {
+	for f.Kind() == reflect.Ptr && !f.IsNil() && f.Elem().Kind() == reflect.Slice {
+		f = f.Elem()
+	}
+	if f.Kind() != reflect.Slice {
+		hide(f)
+		return
+	}
+	for i := 0; i < f.Len(); i++ {
+		hide(f.Index(i))
+	}
+}
+
+// --------------------------------------- decoding ---------------------------------------
+
+// decoder unmarshals query result json into result
+type decoder func(data []byte, result interface{}) error
+
+// newDecoder returns decoder, which maps language tagged keys of query results to json tags of result struct.
+// Falls back to json.Unmarshal, when there's nothing to map.
+func newDecoder(result interface{}, o *options) decoder {
+	t, ok := structType(reflect.TypeOf(result))
+	if !ok {
+		return json.Unmarshal
+	}
+	renames := make(map[string]string) // queried key -> json name
+	var collects []string              // predicates collected into predicate@* map
+	for i := 0; i < t.NumField(); i++ {
+		name, ok := jsonName(t.Field(i))
+		if !ok {
+			continue
+		}
+		p, lang, ok := splitLang(name)
+		if !ok {
+			continue
+		}
+		if lang == "*" {
+			collects = append(collects, p)
+		} else if q := langPredicate(p, lang, o); q != name {
+			renames[q] = name
+		}
+	}
+	if len(renames) == 0 && len(collects) == 0 {
+		return json.Unmarshal
+	}
+
+	return func(data []byte, result interface{}) error {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		mapLangKeysTree(v, renames, collects)
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, result)
+	}
+}
+
+// mapLangKeysTree applies mapLangKeys to every node of decoded json value, including nested ones, like results of Walk
+func mapLangKeysTree(v interface{}, renames map[string]string, collects []string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, child := range v {
+			mapLangKeysTree(child, renames, collects)
+		}
+		mapLangKeys(v, renames, collects)
+	case []interface{}:
+		for _, node := range v {
+			mapLangKeysTree(node, renames, collects)
+		}
+	}
+}
+
+// mapLangKeys renames queried keys of node to json names, and collects all language variants of predicates into predicate@* maps
+func mapLangKeys(node map[string]interface{}, renames map[string]string, collects []string) {
+	for from, to := range renames {
+		if val, ok := node[from]; ok {
+			node[to] = val
+			delete(node, from)
+		}
+	}
+	for _, p := range collects {
+		langs := make(map[string]interface{})
+		for k, val := range node {
+			if k == p {
+				langs[""] = val
+				continue
+			}
+			if lang := strings.TrimPrefix(k, p+"@"); lang != k && isSingleLang(lang) {
+				langs[lang] = val
+			}
+		}
+		if len(langs) > 0 {
+			node[p+"@*"] = langs
+		}
+	}
+}
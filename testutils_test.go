@@ -8,6 +8,7 @@ import (
 
 	"github.com/dgraph-io/dgo"
 	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/ppp225/ndgom"
 	"google.golang.org/grpc"
 )
 
@@ -19,19 +20,88 @@ type testStruct struct {
 	Edge *testStruct `json:"testEdge,omitempty"`
 }
 
+type testFacetStruct struct {
+	UID        string             `json:"uid,omitempty"`
+	Type       []string           `json:"dgraph.type,omitempty" dgtype:"TestType"`
+	Name       string             `json:"testName,omitempty"`
+	NameOrigin string             `json:"testName|origin,omitempty"`
+	Edge       []*testFacetStruct `json:"testEdge,omitempty"`
+	EdgeWeight float64            `json:"testEdge|weight,omitempty"`
+}
+
+type testReverseStruct struct {
+	UID     string              `json:"uid,omitempty"`
+	Type    []string            `json:"dgraph.type,omitempty" dgtype:"TestType"`
+	Name    string              `json:"testName,omitempty"`
+	Edge    []testReverseStruct `json:"testEdge,omitempty"`
+	Parents []testReverseStruct `json:"~testEdge,omitempty"`
+}
+
+type testLangStruct struct {
+	UID       string            `json:"uid,omitempty"`
+	Type      []string          `json:"dgraph.type,omitempty" dgtype:"TestType"`
+	Name      string            `json:"testName,omitempty"`
+	NameEn    string            `json:"testName@en,omitempty"`
+	NameFr    string            `json:"testName@fr,omitempty"`
+	NamePref  string            `json:"testName@.,omitempty"`
+	NameLangs map[string]string `json:"testName@*,omitempty"`
+	Edge      []testLangStruct  `json:"testEdge,omitempty"`
+}
+
+type testGeoStruct struct {
+	UID      string          `json:"uid,omitempty"`
+	Type     []string        `json:"dgraph.type,omitempty" dgtype:"TestType"`
+	Name     string          `json:"testName,omitempty"`
+	Location *ndgom.GeoPoint `json:"testLocation,omitempty"`
+}
+
+type testCountStruct struct {
+	UID   string   `json:"uid,omitempty"`
+	Type  []string `json:"dgraph.type,omitempty" dgtype:"TestType"`
+	Name  string   `json:"testName,omitempty"`
+	Count int      `json:"testCount,omitempty" order:"asc"`
+}
+
+type testOwnedStruct struct {
+	UID  string            `json:"uid,omitempty"`
+	Type []string          `json:"dgraph.type,omitempty" dgtype:"TestType"`
+	Name string            `json:"testName,omitempty"`
+	Edge []testOwnedStruct `json:"testEdge,omitempty" owned:"true"`
+}
+
+type testNoteStruct struct {
+	UID   string   `json:"uid,omitempty"`
+	Type  []string `json:"dgraph.type,omitempty" dgtype:"TestType"`
+	Name  string   `json:"testName,omitempty"`
+	Attr  string   `json:"testAttribute,omitempty"`
+	Count int      `json:"testCount,omitempty"`
+	Note  string   `json:"testNote,omitempty"`
+}
+
+type testOtherStruct struct {
+	UID   string      `json:"uid,omitempty"`
+	Type  []string    `json:"dgraph.type,omitempty" dgtype:"TestOtherType"`
+	Name  string      `json:"testName,omitempty"`
+	Count int         `json:"testCount,omitempty"`
+	Edge  []ndgom.Any `json:"testEdge,omitempty"`
+}
+
 const (
-	predicateName = "testName"
-	predicateAttr = "testAttribute"
-	predicateEdge = "testEdge"
-	firstName     = "first"
-	secondName    = "second"
-	thirdName     = "third"
-	fourthName    = "4444"
-	firstAttr     = "attribute"
-	secondAttr    = "attributer"
-	thirdAttr     = "attributest"
-	fourthAttr    = "40404"
-	testType      = "TestType"
+	predicateName  = "testName"
+	predicateAttr  = "testAttribute"
+	predicateEdge  = "testEdge"
+	predicateLoc   = "testLocation"
+	predicateCount = "testCount"
+	predicateNote  = "testNote"
+	firstName      = "first"
+	secondName     = "second"
+	thirdName      = "third"
+	fourthName     = "4444"
+	firstAttr      = "attribute"
+	secondAttr     = "attributer"
+	thirdAttr      = "attributest"
+	fourthAttr     = "40404"
+	testType       = "TestType"
 )
 
 // dgNewClient creates new *dgo.Dgraph Client
@@ -61,14 +131,26 @@ func dgAddSchema(dg *dgo.Dgraph) {
 	ctx := context.Background()
 	err := dg.Alter(ctx, &api.Operation{
 		Schema: `
-		<testName>: string @index(hash) @upsert .
-		<testAttribute>: string .
-		<testEdge>: [uid] .
+		<testName>: string @index(hash) @upsert @lang .
+		<testAttribute>: string @index(term, fulltext, trigram) .
+		<testEdge>: [uid] @reverse .
+		<testLocation>: geo @index(geo) .
+		<testCount>: int @index(int) .
+		<testNote>: string .
 
 		type TestType {
 			testName: string
 			testAttribute: string
 			testEdge: uid
+			testLocation: geo
+			testCount: int
+			testNote: string
+		  }
+
+		type TestOtherType {
+			testName: string
+			testCount: int
+			testEdge: [uid]
 		  }
 		`,
 	})
@@ -95,16 +177,12 @@ func dgDropTestPredicates(dg *dgo.Dgraph) {
 		}
 		break
 	}
-	err := dg.Alter(ctx, &api.Operation{
-		DropAttr: predicateAttr,
-	})
-	if err != nil {
-		log.Fatal(err)
-	}
-	err = dg.Alter(ctx, &api.Operation{
-		DropAttr: predicateEdge,
-	})
-	if err != nil {
-		log.Fatal(err)
+	for _, predicate := range []string{predicateAttr, predicateEdge, predicateLoc, predicateCount, predicateNote} {
+		err := dg.Alter(ctx, &api.Operation{
+			DropAttr: predicate,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 }
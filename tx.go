@@ -0,0 +1,113 @@
+package ndgom
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dgraph-io/dgo"
+)
+
+// Tx is a managed transaction, see Easy{}.Tx.
+// Its methods mirror Simple{}.API, using the managed transaction.
+type Tx struct {
+	txn Txn
+}
+
+// Txn returns underlying transaction, i.e. to use it with Stateless{}.API
+func (tx *Tx) Txn() Txn {
+	return tx.txn
+}
+
+// GetByID makes db query by uid and unmarshals result as object
+func (tx *Tx) GetByID(uid string, result interface{}, opts ...Option) (err error) {
+	return Simple{}.GetByID(tx.txn, uid, result, opts...)
+}
+
+// Get makes db query and unmarshals results as array
+func (tx *Tx) Get(predicate, value string, result interface{}, opts ...Option) (err error) {
+	return Simple{}.Get(tx.txn, predicate, value, result, opts...)
+}
+
+// GetOne makes db query and unmarshals first result as object
+func (tx *Tx) GetOne(predicate, value string, result interface{}, opts ...Option) (err error) {
+	return Simple{}.GetOne(tx.txn, predicate, value, result, opts...)
+}
+
+// New creates new node. Do not set UID or Type.
+// Can set Type if multiple needed.
+func (tx *Tx) New(obj interface{}) (err error) {
+	return Simple{}.New(tx.txn, obj)
+}
+
+// Upd updates node based on uid and changed fields, and unmarshals updated result into supplied obj.
+// Empty fields are not updated, unless set with Clear option, which deletes them.
+func (tx *Tx) Upd(obj interface{}, opts ...Option) (err error) {
+	return Simple{}.Upd(tx.txn, obj, opts...)
+}
+
+// UpdFields updates only listed fields of node, set by Go field names or predicates, and unmarshals updated result into supplied obj.
+// Other fields are left untouched, even if they are not empty. Listed fields, which are empty, are deleted.
+func (tx *Tx) UpdFields(obj interface{}, fields ...string) (err error) {
+	return Simple{}.UpdFields(tx.txn, obj, fields...)
+}
+
+// Del deletes node based on uid and type. Recursively deletes all nodes reachable through edges tagged with owned:"true".
+func (tx *Tx) Del(obj interface{}) (err error) {
+	return Simple{}.Del(tx.txn, obj)
+}
+
+// Tx runs fn in a managed transaction and commits it, if fn returns no error.
+// Transaction is discarded when fn returns an error or panics.
+// When transaction is aborted due to conflict, whole fn is retried with exponential backoff, see SetTxRetries.
+// As fn may run multiple times, it should not have side effects outside of tx.
+func (Easy) Tx(ctx context.Context, fn func(tx *Tx) error) (err error) {
+	backoff := txBackoff
+	for attempt := 0; ; attempt++ {
+		err = runTx(ctx, fn)
+		if !errors.Is(err, dgo.ErrAborted) || attempt >= txRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// ReadTx runs fn in a managed read transaction, of kind set by SetReadMode.
+// Transaction is never committed, so mutations in fn will fail or be discarded.
+// Read only transactions don't conflict, so fn is not retried.
+func (Easy) ReadTx(ctx context.Context, fn func(tx *Tx) error) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, txnTimeout)
+	defer cancel()
+	txn := newReadTxn(ctx)
+	defer txn.Discard()
+
+	return fn(&Tx{txn: txn})
+}
+
+// SetTxRetries sets how many times Easy{}.Tx retries aborted transactions, and the initial backoff between retries.
+// If backoff is 0, current value will be kept. Defaults are 3 retries and 10 milliseconds.
+func (Easy) SetTxRetries(retries int, backoff time.Duration) {
+	txRetries = retries
+	if backoff > 0 {
+		txBackoff = backoff
+	}
+}
+
+// runTx runs single attempt of managed transaction
+func runTx(ctx context.Context, fn func(tx *Tx) error) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, txnTimeout)
+	defer cancel()
+	txn := newTxn(ctx)
+	defer txn.Discard()
+
+	err = fn(&Tx{txn: txn})
+	if err != nil {
+		return err
+	}
+	return txn.Commit()
+}
@@ -0,0 +1,114 @@
+package ndgom
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dgraph-io/dgo"
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/ppp225/ndgo"
+)
+
+// Txn is the transaction interface all ndgom APIs work on.
+// Implement it to wrap, mock or instrument transactions,
+// or use one of the provided adapters: NewNdgoTxn, NewDgoTxn.
+type Txn interface {
+	// Seti runs json mutation of supplied objects
+	Seti(jsonMutations ...interface{}) (*api.Response, error)
+	// DoSetb runs upsert block with query, condition and json/rdf set mutation
+	DoSetb(query, cond string, setJSON, setRDF []byte) (*api.Response, error)
+	// Do runs request, which can be a query, mutation or upsert with multiple mutations
+	Do(req *api.Request) (*api.Response, error)
+	// Query runs DQL query
+	Query(query string) (*api.Response, error)
+	// Commit commits the transaction
+	Commit() error
+	// Discard cleans up transaction resources. Always defer it after creating new transaction.
+	Discard()
+}
+
+// --------------------------------------- ndgo ---------------------------------------
+
+type ndgoTxn struct {
+	txn *ndgo.Txn
+}
+
+// NewNdgoTxn adapts *ndgo.Txn to ndgom.Txn
+func NewNdgoTxn(txn *ndgo.Txn) Txn {
+	return &ndgoTxn{txn: txn}
+}
+
+func (v *ndgoTxn) Seti(jsonMutations ...interface{}) (*api.Response, error) {
+	return v.txn.Seti(jsonMutations...)
+}
+
+func (v *ndgoTxn) DoSetb(query, cond string, setJSON, setRDF []byte) (*api.Response, error) {
+	return v.txn.DoSetb(query, cond, setJSON, setRDF)
+}
+
+func (v *ndgoTxn) Do(req *api.Request) (*api.Response, error) {
+	return v.txn.Do(req)
+}
+
+func (v *ndgoTxn) Query(query string) (*api.Response, error) {
+	return v.txn.Query(query)
+}
+
+func (v *ndgoTxn) Commit() error {
+	return v.txn.Commit()
+}
+
+func (v *ndgoTxn) Discard() {
+	v.txn.Discard()
+}
+
+// --------------------------------------- dgo ---------------------------------------
+
+type dgoTxn struct {
+	ctx context.Context
+	txn *dgo.Txn
+}
+
+// NewDgoTxn adapts raw *dgo.Txn to ndgom.Txn. All operations will use supplied ctx.
+func NewDgoTxn(ctx context.Context, txn *dgo.Txn) Txn {
+	return &dgoTxn{ctx: ctx, txn: txn}
+}
+
+func (v *dgoTxn) Seti(jsonMutations ...interface{}) (*api.Response, error) {
+	var obj interface{} = jsonMutations
+	if len(jsonMutations) == 1 {
+		obj = jsonMutations[0]
+	}
+	jsonBytes, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return v.txn.Mutate(v.ctx, &api.Mutation{SetJson: jsonBytes})
+}
+
+func (v *dgoTxn) DoSetb(query, cond string, setJSON, setRDF []byte) (*api.Response, error) {
+	return v.txn.Do(v.ctx, &api.Request{
+		Query: query,
+		Mutations: []*api.Mutation{{
+			Cond:      cond,
+			SetJson:   setJSON,
+			SetNquads: setRDF,
+		}},
+	})
+}
+
+func (v *dgoTxn) Do(req *api.Request) (*api.Response, error) {
+	return v.txn.Do(v.ctx, req)
+}
+
+func (v *dgoTxn) Query(query string) (*api.Response, error) {
+	return v.txn.Query(v.ctx, query)
+}
+
+func (v *dgoTxn) Commit() error {
+	return v.txn.Commit(v.ctx)
+}
+
+func (v *dgoTxn) Discard() {
+	v.txn.Discard(v.ctx)
+}
@@ -0,0 +1,197 @@
+package ndgom
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/dgo"
+)
+
+// VerifyModels compares json tagged fields and dgtype of model structs with live schema, and reports all mismatches in returned error.
+// Structs reachable through edges are verified too. If no models are given, registered models are verified, see Register.
+// Usage at startup: err := ndgom.Admin{}.VerifyModels(dg, &Person{}, &Company{})
+func (Admin) VerifyModels(dg *dgo.Dgraph, models ...interface{}) (err error) {
+	schema, err := Admin{}.GetSchema(dg)
+	if err != nil {
+		return err
+	}
+	if len(models) == 0 {
+		models = registeredModels()
+	}
+	if mismatches := verifyModels(schema, models); len(mismatches) > 0 {
+		return fmt.Errorf("ndgom.Admin{}.VerifyModels: %w:\n%s", ErrSchemaMismatch, strings.Join(mismatches, "\n"))
+	}
+	return nil
+}
+
+// registeredModels returns pointers to new structs of all registered types, sorted by dgraph type
+func registeredModels() (models []interface{}) {
+	registry.RLock()
+	defer registry.RUnlock()
+	dgTypes := make([]string, 0, len(registry.types))
+	for dgType := range registry.types {
+		dgTypes = append(dgTypes, dgType)
+	}
+	sort.Strings(dgTypes)
+	for _, dgType := range dgTypes {
+		models = append(models, reflect.New(registry.types[dgType]).Interface())
+	}
+	return models
+}
+
+// verifyModels returns mismatches between models and schema
+func verifyModels(schema *Schema, models []interface{}) (mismatches []string) {
+	visited := make(map[reflect.Type]bool)
+	var verify func(t reflect.Type)
+	verify = func(t reflect.Type) {
+		if visited[t] {
+			return
+		}
+		visited[t] = true
+		var dgType *SchemaType
+		if name := parseTagDgType(t); name != "_all_" {
+			if st, ok := schema.Type(name); ok {
+				dgType = &st
+			} else {
+				mismatches = append(mismatches, fmt.Sprintf("%s: type %s does not exist", t.Name(), name))
+			}
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, ok := jsonName(field)
+			if !ok || name == "uid" || name == "dgraph.type" {
+				continue
+			}
+			if _, _, ok := splitFacet(name); ok {
+				continue
+			}
+			mismatches = append(mismatches, verifyField(schema, dgType, t, field, name)...)
+			if et, ok := edgeType(field.Type); ok {
+				verify(et)
+			}
+		}
+	}
+	for _, model := range models {
+		t, ok := structType(reflect.TypeOf(model))
+		if !ok {
+			panic(fmt.Sprintf("ndgom.VerifyModels: model must be a struct, but is: %T", model))
+		}
+		verify(t)
+	}
+	return mismatches
+}
+
+// verifyField returns mismatches between struct field of predicate name and schema of the predicate and type
+func verifyField(schema *Schema, dgType *SchemaType, t reflect.Type, field reflect.StructField, name string) (mismatches []string) {
+	where := t.Name() + "." + field.Name
+	predicate := strings.TrimPrefix(name, "~")
+	p, lang, isLang := splitLang(predicate)
+	if isLang {
+		predicate = p
+	}
+	ps, ok := schema.Predicate(predicate)
+	if !ok {
+		return []string{fmt.Sprintf("%s: predicate %s does not exist", where, predicate)}
+	}
+	if dgType != nil && !isReverse(name) && !dgType.HasField(predicate) {
+		mismatches = append(mismatches, fmt.Sprintf("%s: type %s misses field %s", where, dgType.Name, predicate))
+	}
+
+	ft := field.Type
+	for ft.Kind() == reflect.Ptr {
+		ft = ft.Elem()
+	}
+	isSlice := ft.Kind() == reflect.Slice && ft.Elem().Kind() != reflect.Uint8
+	_, isEdge := edgeType(field.Type)
+	if st, _ := structType(field.Type); st == anyType {
+		isEdge = true
+	}
+	switch {
+	case isReverse(name):
+		if ps.Type != "uid" || !ps.Reverse {
+			mismatches = append(mismatches, fmt.Sprintf("%s: reverse edge %s needs uid predicate %s with @reverse", where, name, predicate))
+		}
+	case isLang:
+		if !ps.Lang {
+			mismatches = append(mismatches, fmt.Sprintf("%s: language tagged field needs @lang on %s", where, predicate))
+		}
+		if lang == "*" {
+			break
+		}
+		if ft.Kind() != reflect.String || ps.Type != "string" {
+			mismatches = append(mismatches, fmt.Sprintf("%s: language tagged field needs string, but %s is %s", where, predicate, ps.Type))
+		}
+	case isEdge:
+		if ps.Type != "uid" {
+			mismatches = append(mismatches, fmt.Sprintf("%s: edge needs uid, but %s is %s", where, predicate, ps.Type))
+			break
+		}
+		if isSlice && !ps.List {
+			mismatches = append(mismatches, fmt.Sprintf("%s: slice field needs [uid], but %s is uid", where, predicate))
+		}
+		if !isSlice && ps.List {
+			mismatches = append(mismatches, fmt.Sprintf("%s: %s is [uid], so field needs to be slice", where, predicate))
+		}
+	default:
+		st := ft
+		if isSlice {
+			st = ft.Elem()
+			if !ps.List {
+				mismatches = append(mismatches, fmt.Sprintf("%s: slice field needs [%s], but %s is not a list", where, ps.Type, predicate))
+			}
+		}
+		types, ok := scalarTypes(st)
+		if !ok {
+			break
+		}
+		if !contains(types, ps.Type) {
+			mismatches = append(mismatches, fmt.Sprintf("%s: %s field needs one of %s, but %s is %s", where, st, strings.Join(types, ", "), predicate, ps.Type))
+		}
+	}
+	return mismatches
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	geoType        = reflect.TypeOf((*Geo)(nil)).Elem()
+	geoPointType   = reflect.TypeOf(GeoPoint{})
+	geoPolygonType = reflect.TypeOf(GeoPolygon{})
+)
+
+// scalarTypes returns schema types values of Go type t can be decoded from. ok is false for types which can't be checked, like custom ones.
+func scalarTypes(t reflect.Type) (types []string, ok bool) {
+	switch t {
+	case timeType:
+		return []string{"datetime"}, true
+	case geoType, geoPointType, geoPolygonType:
+		return []string{"geo"}, true
+	}
+	if isScalarType(t) {
+		return nil, false
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return []string{"string", "default", "datetime", "password"}, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []string{"int"}, true
+	case reflect.Float32, reflect.Float64:
+		return []string{"float", "int"}, true
+	case reflect.Bool:
+		return []string{"bool"}, true
+	}
+	return nil, false
+}
+
+// contains checks if s is in list
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package ndgom
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ppp225/ndgo"
+)
+
+// AllowLoops allows Walk to visit already visited nodes, up to the depth
+func AllowLoops() Option {
+	return func(o *options) {
+		o.loop = true
+	}
+}
+
+// Walk makes recursive db query from node of uid, traversing edge of given struct field name up to depth,
+// and unmarshals result as tree of the same struct type. Result must be *struct, with edge pointing to the same struct type.
+func (Simple) Walk(txn Txn, uid, edgeField string, depth int, result interface{}, opts ...Option) (err error) {
+	if err = validateInput(result); err != nil {
+		return err
+	}
+	o := newOptions(opts)
+	predicates, err := walkPredicates(reflect.TypeOf(result).Elem(), edgeField, o)
+	if err != nil {
+		return err
+	}
+	filter := ""
+	if dgType := getDgType(result); dgType != "_all_" {
+		filter = filterOf([]Cond{{function: "type", args: dgType}})
+	}
+	q := fmt.Sprintf(`
+	{
+	  q(func: uid(%s)) %s @recurse(depth: %d, loop: %t) {
+	    %s
+	  }
+	}`, uid, filter, depth, o.loop, predicates)
+	resp, err := txn.Query(q)
+	if err != nil {
+		return err
+	}
+	return newDecoder(result, o)(ndgo.Unsafe{}.FlattenRespToObject(resp.GetJson()), &result)
+}
+
+// Walk makes recursive db query from root, traversing edge of given struct field name up to depth,
+// and unmarshals result as tree of the same struct type. Root needs to have UID set. Result can be root itself.
+func (Easy) Walk(root interface{}, edgeField string, depth int, result interface{}, opts ...Option) (err error) {
+	uid := getUID(root)
+	return withReadTxn(func(txn Txn) error {
+		return Simple{}.Walk(txn, uid, edgeField, depth, result, opts...)
+	})
+}
+
+// walkPredicates returns predicates to query in every level of recursion: all scalar predicates of struct t, and the walked edge.
+// Recurse doesn't support expand(_all_), so all predicates are listed explicitly.
+func walkPredicates(t reflect.Type, edgeField string, o *options) (string, error) {
+	if t.Kind() != reflect.Struct {
+		return "", fmt.Errorf("ndgom.walkPredicates: %w, result must be *struct", ErrWrongInput)
+	}
+	edge, ok := t.FieldByName(edgeField)
+	if !ok {
+		return "", fmt.Errorf("ndgom.walkPredicates: struct %s has no field %s", t.Name(), edgeField)
+	}
+	edgeName, ok := jsonName(edge)
+	if et, isEdge := edgeType(edge.Type); !ok || !isEdge || et != t {
+		return "", fmt.Errorf("ndgom.walkPredicates: field %s needs to be json tagged edge to %s", edgeField, t.Name())
+	}
+	predicates := []string{"uid"}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonName(field)
+		if !ok || name == "uid" {
+			continue
+		}
+		if _, _, isFacet := splitFacet(name); isFacet {
+			continue
+		}
+		if _, isEdge := edgeType(field.Type); isEdge {
+			continue
+		}
+		if p, lang, isLang := splitLang(name); isLang {
+			if lang != "*" {
+				predicates = append(predicates, langPredicate(p, lang, o))
+			}
+			continue
+		}
+		predicates = append(predicates, name)
+	}
+	predicates = append(predicates, edgeName)
+	return strings.Join(predicates, " "), nil
+}
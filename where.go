@@ -0,0 +1,130 @@
+package ndgom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cond is a condition on predicate, used in Where option or as root function. Create it with Eq, Ge, Gt, Le, Lt, Between, UID, Has or Type.
+type Cond struct {
+	function  string
+	predicate string
+	args      string
+}
+
+// String returns condition as dgraph function, i.e. ge(predicate, 5)
+func (c Cond) String() string {
+	if c.predicate == "" { // functions without predicate, like uid(0x1) or type(Name)
+		return fmt.Sprintf("%s(%s)", c.function, c.args)
+	}
+	return fmt.Sprintf("%s(%s, %s)", c.function, c.predicate, c.args)
+}
+
+// Eq matches predicate equal to value
+func Eq(predicate string, value interface{}) Cond {
+	return Cond{function: "eq", predicate: predicate, args: formatValue(value)}
+}
+
+// Ge matches predicate greater than or equal to value
+func Ge(predicate string, value interface{}) Cond {
+	return Cond{function: "ge", predicate: predicate, args: formatValue(value)}
+}
+
+// Gt matches predicate greater than value
+func Gt(predicate string, value interface{}) Cond {
+	return Cond{function: "gt", predicate: predicate, args: formatValue(value)}
+}
+
+// Le matches predicate less than or equal to value
+func Le(predicate string, value interface{}) Cond {
+	return Cond{function: "le", predicate: predicate, args: formatValue(value)}
+}
+
+// Lt matches predicate less than value
+func Lt(predicate string, value interface{}) Cond {
+	return Cond{function: "lt", predicate: predicate, args: formatValue(value)}
+}
+
+// Between matches predicate between from and to, inclusive
+func Between(predicate string, from, to interface{}) Cond {
+	return Cond{function: "between", predicate: predicate, args: formatValue(from) + ", " + formatValue(to)}
+}
+
+// UID matches nodes of given uids
+func UID(uids ...string) Cond {
+	return Cond{function: "uid", args: strings.Join(uids, ", ")}
+}
+
+// Has matches nodes, which have predicate
+func Has(predicate string) Cond {
+	return Cond{function: "has", args: predicate}
+}
+
+// Type matches nodes of dgraph type
+func Type(dgType string) Cond {
+	return Cond{function: "type", args: dgType}
+}
+
+// Where filters query results by all of the conditions, i.e. ndgom.Where(ndgom.Gt("created", time.Now().Add(-24*time.Hour))).
+// In Easy{}.Get, when no fields of example are populated, first condition is used as root function.
+// Predicates used in root function need to be indexed.
+func Where(conds ...Cond) Option {
+	return func(o *options) {
+		o.conds = append(o.conds, conds...)
+	}
+}
+
+// matchConds returns conditions matching nodes of example type, populated fields of example and Where conditions.
+// First condition is meant as root function: uid if set, else eq on populated field with index usable by eq, see rootPredicate.
+// Other populated fields follow in sorted order. Example can be *struct or *[]struct.
+func matchConds(txn Txn, example interface{}, o *options) (conds []Cond, err error) {
+	f := getPopulatedFields(example, getKind(example), o)
+	if uid, ok := f["uid"]; ok {
+		conds = append(conds, Cond{function: "uid", args: uid})
+		delete(f, "uid")
+	} else if len(f) > 0 {
+		predicate, err := rootPredicate(txn, f)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, Cond{function: "eq", predicate: predicate, args: f[predicate]})
+		delete(f, predicate)
+	}
+	for _, k := range sortedKeys(f) {
+		conds = append(conds, Cond{function: "eq", predicate: k, args: f[k]})
+	}
+	conds = append(conds, o.conds...)
+	if dgType := getDgType(example); dgType != "_all_" {
+		conds = append(conds, Cond{function: "type", args: dgType})
+	}
+	if len(conds) == 0 {
+		return nil, fmt.Errorf("ndgom.matchConds: need dgtype tag, at least one populated struct field or Where condition")
+	}
+	return conds, nil
+}
+
+// formatValue formats value as dgraph function argument
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return strconv.Quote(v.Format(time.RFC3339Nano))
+	case string:
+		return strconv.Quote(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// filterOf returns @filter directive matching all conds, or empty string if there are none
+func filterOf(conds []Cond) string {
+	if len(conds) == 0 {
+		return ""
+	}
+	filters := make([]string, len(conds))
+	for i, c := range conds {
+		filters[i] = c.String()
+	}
+	return "@filter(" + strings.Join(filters, " AND ") + ")"
+}